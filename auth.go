@@ -1,6 +1,7 @@
 package slack
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 )
@@ -30,8 +31,13 @@ type OAuthAccessResponse struct {
 
 // AuthTest tests if the authentication is in place - see https://api.slack.com/methods/auth.test
 func (s *Slack) AuthTest() (*AuthTestResponse, error) {
+	return s.AuthTestContext(context.Background())
+}
+
+// AuthTestContext is AuthTest with a custom context
+func (s *Slack) AuthTestContext(ctx context.Context) (*AuthTestResponse, error) {
 	r := &AuthTestResponse{}
-	err := s.do("auth.test", url.Values{}, r)
+	err := s.do(ctx, "auth.test", url.Values{}, r)
 	if err != nil {
 		return nil, err
 	}
@@ -40,6 +46,11 @@ func (s *Slack) AuthTest() (*AuthTestResponse, error) {
 
 // OAuthAccess returns the token for OAuth
 func OAuthAccess(clientID, clientSecret, code, redirectURI string) (*OAuthAccessResponse, error) {
+	return OAuthAccessContext(context.Background(), clientID, clientSecret, code, redirectURI)
+}
+
+// OAuthAccessContext is OAuthAccess with a custom context
+func OAuthAccessContext(ctx context.Context, clientID, clientSecret, code, redirectURI string) (*OAuthAccessResponse, error) {
 	params := url.Values{
 		"client_id":     {clientID},
 		"client_secret": {clientSecret},
@@ -53,7 +64,7 @@ func OAuthAccess(clientID, clientSecret, code, redirectURI string) (*OAuthAccess
 		c:   http.DefaultClient,
 	}
 	r := &OAuthAccessResponse{}
-	err := s.do("oauth.access", params, r)
+	err := s.do(ctx, "oauth.access", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,478 @@
+package slack
+
+import (
+	"encoding/json"
+)
+
+// Block element types - see https://api.slack.com/reference/block-kit/block-elements
+const (
+	ElementButton                   = "button"
+	ElementImage                    = "image"
+	ElementStaticSelect             = "static_select"
+	ElementExternalSelect           = "external_select"
+	ElementUsersSelect              = "users_select"
+	ElementConversationsSelect      = "conversations_select"
+	ElementChannelsSelect           = "channels_select"
+	ElementMultiStaticSelect        = "multi_static_select"
+	ElementMultiExternalSelect      = "multi_external_select"
+	ElementMultiUsersSelect         = "multi_users_select"
+	ElementMultiConversationsSelect = "multi_conversations_select"
+	ElementMultiChannelsSelect      = "multi_channels_select"
+	ElementOverflow                 = "overflow"
+	ElementDatePicker               = "datepicker"
+	ElementTimePicker               = "timepicker"
+	ElementPlainTextInput           = "plain_text_input"
+	ElementCheckboxes               = "checkboxes"
+	ElementRadioButtons             = "radio_buttons"
+)
+
+// Button styles
+const (
+	StylePrimary = "primary"
+	StyleDanger  = "danger"
+)
+
+// BlockElement is implemented by all the block elements and by text objects which can be used in context blocks
+type BlockElement interface {
+	ElementType() string
+	Validate() error
+}
+
+// ButtonElement is an interactive button
+type ButtonElement struct {
+	Type     string              `json:"type"`
+	Text     *TextObject         `json:"text"`
+	ActionID string              `json:"action_id,omitempty"`
+	URL      string              `json:"url,omitempty"`
+	Value    string              `json:"value,omitempty"`
+	Style    string              `json:"style,omitempty"`
+	Confirm  *ConfirmationObject `json:"confirm,omitempty"`
+}
+
+// NewButton creates a button with plain text
+func NewButton(actionID, text, value string) *ButtonElement {
+	return &ButtonElement{Type: ElementButton, Text: NewPlainText(text), ActionID: actionID, Value: value}
+}
+
+// ElementType returns the type of the element
+func (e *ButtonElement) ElementType() string {
+	return e.Type
+}
+
+// Validate the button
+func (e *ButtonElement) Validate() error {
+	if e.Text == nil {
+		return newError("invalid_blocks", "button text is required")
+	}
+	if err := e.Text.validate("button text", 75, true); err != nil {
+		return err
+	}
+	if err := maxLength("action_id", e.ActionID, 255); err != nil {
+		return err
+	}
+	if err := maxLength("button url", e.URL, 3000); err != nil {
+		return err
+	}
+	if err := maxLength("button value", e.Value, 2000); err != nil {
+		return err
+	}
+	if e.Style != "" && e.Style != StylePrimary && e.Style != StyleDanger {
+		return newError("invalid_blocks", "invalid button style [%s]", e.Style)
+	}
+	return e.Confirm.Validate()
+}
+
+// ImageElement is an image in a section accessory or context block
+type ImageElement struct {
+	Type     string `json:"type"`
+	ImageURL string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+}
+
+// NewImageElement creates an image element
+func NewImageElement(imageURL, altText string) *ImageElement {
+	return &ImageElement{Type: ElementImage, ImageURL: imageURL, AltText: altText}
+}
+
+// ElementType returns the type of the element
+func (e *ImageElement) ElementType() string {
+	return e.Type
+}
+
+// Validate the image
+func (e *ImageElement) Validate() error {
+	if e.ImageURL == "" || e.AltText == "" {
+		return newError("invalid_blocks", "image requires image_url and alt_text")
+	}
+	if err := maxLength("image_url", e.ImageURL, 3000); err != nil {
+		return err
+	}
+	return maxLength("alt_text", e.AltText, 2000)
+}
+
+// SelectElement is a single select menu of any of the select types
+type SelectElement struct {
+	Type                string               `json:"type"`
+	Placeholder         *TextObject          `json:"placeholder,omitempty"`
+	ActionID            string               `json:"action_id,omitempty"`
+	Options             []*OptionObject      `json:"options,omitempty"`
+	OptionGroups        []*OptionGroupObject `json:"option_groups,omitempty"`
+	InitialOption       *OptionObject        `json:"initial_option,omitempty"`
+	InitialUser         string               `json:"initial_user,omitempty"`
+	InitialConversation string               `json:"initial_conversation,omitempty"`
+	InitialChannel      string               `json:"initial_channel,omitempty"`
+	MinQueryLength      int                  `json:"min_query_length,omitempty"`
+	Confirm             *ConfirmationObject  `json:"confirm,omitempty"`
+}
+
+// NewStaticSelect creates a static select menu with the given options
+func NewStaticSelect(actionID, placeholder string, options ...*OptionObject) *SelectElement {
+	return &SelectElement{Type: ElementStaticSelect, ActionID: actionID, Placeholder: NewPlainText(placeholder), Options: options}
+}
+
+// NewSelect creates a select menu of the given type, for example ElementUsersSelect
+func NewSelect(selectType, actionID, placeholder string) *SelectElement {
+	return &SelectElement{Type: selectType, ActionID: actionID, Placeholder: NewPlainText(placeholder)}
+}
+
+// ElementType returns the type of the element
+func (e *SelectElement) ElementType() string {
+	return e.Type
+}
+
+// Validate the select menu
+func (e *SelectElement) Validate() error {
+	if err := e.Placeholder.validate("placeholder", 150, true); err != nil {
+		return err
+	}
+	if err := maxLength("action_id", e.ActionID, 255); err != nil {
+		return err
+	}
+	if e.Type == ElementStaticSelect && len(e.Options) == 0 && len(e.OptionGroups) == 0 {
+		return newError("invalid_blocks", "static select requires options or option groups")
+	}
+	if err := validateOptions(e.Options, 100); err != nil {
+		return err
+	}
+	if len(e.OptionGroups) > 100 {
+		return newError("invalid_blocks", "more than 100 option groups")
+	}
+	for _, g := range e.OptionGroups {
+		if err := g.Validate(); err != nil {
+			return err
+		}
+	}
+	return e.Confirm.Validate()
+}
+
+// MultiSelectElement is a multi select menu of any of the multi select types
+type MultiSelectElement struct {
+	Type                 string               `json:"type"`
+	Placeholder          *TextObject          `json:"placeholder,omitempty"`
+	ActionID             string               `json:"action_id,omitempty"`
+	Options              []*OptionObject      `json:"options,omitempty"`
+	OptionGroups         []*OptionGroupObject `json:"option_groups,omitempty"`
+	InitialOptions       []*OptionObject      `json:"initial_options,omitempty"`
+	InitialUsers         []string             `json:"initial_users,omitempty"`
+	InitialConversations []string             `json:"initial_conversations,omitempty"`
+	InitialChannels      []string             `json:"initial_channels,omitempty"`
+	MaxSelectedItems     int                  `json:"max_selected_items,omitempty"`
+	MinQueryLength       int                  `json:"min_query_length,omitempty"`
+	Confirm              *ConfirmationObject  `json:"confirm,omitempty"`
+}
+
+// NewMultiSelect creates a multi select menu of the given type, for example ElementMultiUsersSelect
+func NewMultiSelect(selectType, actionID, placeholder string, options ...*OptionObject) *MultiSelectElement {
+	return &MultiSelectElement{Type: selectType, ActionID: actionID, Placeholder: NewPlainText(placeholder), Options: options}
+}
+
+// ElementType returns the type of the element
+func (e *MultiSelectElement) ElementType() string {
+	return e.Type
+}
+
+// Validate the multi select menu
+func (e *MultiSelectElement) Validate() error {
+	if err := e.Placeholder.validate("placeholder", 150, true); err != nil {
+		return err
+	}
+	if err := maxLength("action_id", e.ActionID, 255); err != nil {
+		return err
+	}
+	if err := validateOptions(e.Options, 100); err != nil {
+		return err
+	}
+	for _, g := range e.OptionGroups {
+		if err := g.Validate(); err != nil {
+			return err
+		}
+	}
+	return e.Confirm.Validate()
+}
+
+// OverflowElement is an overflow menu
+type OverflowElement struct {
+	Type     string              `json:"type"`
+	ActionID string              `json:"action_id,omitempty"`
+	Options  []*OptionObject     `json:"options"`
+	Confirm  *ConfirmationObject `json:"confirm,omitempty"`
+}
+
+// NewOverflow creates an overflow menu
+func NewOverflow(actionID string, options ...*OptionObject) *OverflowElement {
+	return &OverflowElement{Type: ElementOverflow, ActionID: actionID, Options: options}
+}
+
+// ElementType returns the type of the element
+func (e *OverflowElement) ElementType() string {
+	return e.Type
+}
+
+// Validate the overflow menu
+func (e *OverflowElement) Validate() error {
+	if len(e.Options) < 2 {
+		return newError("invalid_blocks", "overflow requires at least 2 options")
+	}
+	if err := validateOptions(e.Options, 5); err != nil {
+		return err
+	}
+	return e.Confirm.Validate()
+}
+
+// DatePickerElement is a date picker
+type DatePickerElement struct {
+	Type        string              `json:"type"`
+	ActionID    string              `json:"action_id,omitempty"`
+	Placeholder *TextObject         `json:"placeholder,omitempty"`
+	InitialDate string              `json:"initial_date,omitempty"` // YYYY-MM-DD
+	Confirm     *ConfirmationObject `json:"confirm,omitempty"`
+}
+
+// NewDatePicker creates a date picker
+func NewDatePicker(actionID, placeholder string) *DatePickerElement {
+	return &DatePickerElement{Type: ElementDatePicker, ActionID: actionID, Placeholder: NewPlainText(placeholder)}
+}
+
+// ElementType returns the type of the element
+func (e *DatePickerElement) ElementType() string {
+	return e.Type
+}
+
+// Validate the date picker
+func (e *DatePickerElement) Validate() error {
+	if err := e.Placeholder.validate("placeholder", 150, true); err != nil {
+		return err
+	}
+	return e.Confirm.Validate()
+}
+
+// TimePickerElement is a time picker
+type TimePickerElement struct {
+	Type        string              `json:"type"`
+	ActionID    string              `json:"action_id,omitempty"`
+	Placeholder *TextObject         `json:"placeholder,omitempty"`
+	InitialTime string              `json:"initial_time,omitempty"` // HH:mm
+	Confirm     *ConfirmationObject `json:"confirm,omitempty"`
+}
+
+// NewTimePicker creates a time picker
+func NewTimePicker(actionID, placeholder string) *TimePickerElement {
+	return &TimePickerElement{Type: ElementTimePicker, ActionID: actionID, Placeholder: NewPlainText(placeholder)}
+}
+
+// ElementType returns the type of the element
+func (e *TimePickerElement) ElementType() string {
+	return e.Type
+}
+
+// Validate the time picker
+func (e *TimePickerElement) Validate() error {
+	if err := e.Placeholder.validate("placeholder", 150, true); err != nil {
+		return err
+	}
+	return e.Confirm.Validate()
+}
+
+// PlainTextInputElement is a text input used in input blocks
+type PlainTextInputElement struct {
+	Type         string      `json:"type"`
+	ActionID     string      `json:"action_id,omitempty"`
+	Placeholder  *TextObject `json:"placeholder,omitempty"`
+	InitialValue string      `json:"initial_value,omitempty"`
+	Multiline    bool        `json:"multiline,omitempty"`
+	MinLength    int         `json:"min_length,omitempty"`
+	MaxLength    int         `json:"max_length,omitempty"`
+}
+
+// NewPlainTextInput creates a text input
+func NewPlainTextInput(actionID, placeholder string, multiline bool) *PlainTextInputElement {
+	return &PlainTextInputElement{Type: ElementPlainTextInput, ActionID: actionID, Placeholder: NewPlainText(placeholder), Multiline: multiline}
+}
+
+// ElementType returns the type of the element
+func (e *PlainTextInputElement) ElementType() string {
+	return e.Type
+}
+
+// Validate the text input
+func (e *PlainTextInputElement) Validate() error {
+	if err := e.Placeholder.validate("placeholder", 150, true); err != nil {
+		return err
+	}
+	if e.MinLength < 0 || e.MinLength > 3000 || e.MaxLength < 0 || (e.MaxLength > 0 && e.MaxLength < e.MinLength) {
+		return newError("invalid_blocks", "invalid text input length limits %d - %d", e.MinLength, e.MaxLength)
+	}
+	return nil
+}
+
+// CheckboxesElement is a group of checkboxes
+type CheckboxesElement struct {
+	Type           string              `json:"type"`
+	ActionID       string              `json:"action_id,omitempty"`
+	Options        []*OptionObject     `json:"options"`
+	InitialOptions []*OptionObject     `json:"initial_options,omitempty"`
+	Confirm        *ConfirmationObject `json:"confirm,omitempty"`
+}
+
+// NewCheckboxes creates a group of checkboxes
+func NewCheckboxes(actionID string, options ...*OptionObject) *CheckboxesElement {
+	return &CheckboxesElement{Type: ElementCheckboxes, ActionID: actionID, Options: options}
+}
+
+// ElementType returns the type of the element
+func (e *CheckboxesElement) ElementType() string {
+	return e.Type
+}
+
+// Validate the checkboxes
+func (e *CheckboxesElement) Validate() error {
+	if len(e.Options) == 0 {
+		return newError("invalid_blocks", "checkboxes require options")
+	}
+	if err := validateOptions(e.Options, 10); err != nil {
+		return err
+	}
+	return e.Confirm.Validate()
+}
+
+// RadioButtonsElement is a group of radio buttons
+type RadioButtonsElement struct {
+	Type          string              `json:"type"`
+	ActionID      string              `json:"action_id,omitempty"`
+	Options       []*OptionObject     `json:"options"`
+	InitialOption *OptionObject       `json:"initial_option,omitempty"`
+	Confirm       *ConfirmationObject `json:"confirm,omitempty"`
+}
+
+// NewRadioButtons creates a group of radio buttons
+func NewRadioButtons(actionID string, options ...*OptionObject) *RadioButtonsElement {
+	return &RadioButtonsElement{Type: ElementRadioButtons, ActionID: actionID, Options: options}
+}
+
+// ElementType returns the type of the element
+func (e *RadioButtonsElement) ElementType() string {
+	return e.Type
+}
+
+// Validate the radio buttons
+func (e *RadioButtonsElement) Validate() error {
+	if len(e.Options) == 0 {
+		return newError("invalid_blocks", "radio buttons require options")
+	}
+	if err := validateOptions(e.Options, 10); err != nil {
+		return err
+	}
+	return e.Confirm.Validate()
+}
+
+// UnknownElement holds an element type the library does not know yet
+type UnknownElement struct {
+	Type string          `json:"type"`
+	Raw  json.RawMessage `json:"-"`
+}
+
+// ElementType returns the type of the element
+func (e *UnknownElement) ElementType() string {
+	return e.Type
+}
+
+// Validate does nothing for unknown elements
+func (e *UnknownElement) Validate() error {
+	return nil
+}
+
+// MarshalJSON returns the raw element
+func (e *UnknownElement) MarshalJSON() ([]byte, error) {
+	return e.Raw, nil
+}
+
+// newElement creates an empty element for the given type
+func newElement(elementType string) BlockElement {
+	switch elementType {
+	case PlainTextType, MarkdownType:
+		return &TextObject{}
+	case ElementButton:
+		return &ButtonElement{}
+	case ElementImage:
+		return &ImageElement{}
+	case ElementStaticSelect, ElementExternalSelect, ElementUsersSelect, ElementConversationsSelect, ElementChannelsSelect:
+		return &SelectElement{}
+	case ElementMultiStaticSelect, ElementMultiExternalSelect, ElementMultiUsersSelect, ElementMultiConversationsSelect, ElementMultiChannelsSelect:
+		return &MultiSelectElement{}
+	case ElementOverflow:
+		return &OverflowElement{}
+	case ElementDatePicker:
+		return &DatePickerElement{}
+	case ElementTimePicker:
+		return &TimePickerElement{}
+	case ElementPlainTextInput:
+		return &PlainTextInputElement{}
+	case ElementCheckboxes:
+		return &CheckboxesElement{}
+	case ElementRadioButtons:
+		return &RadioButtonsElement{}
+	}
+	return nil
+}
+
+// decodeElement decodes a single element based on its type
+func decodeElement(p json.RawMessage) (BlockElement, error) {
+	if len(p) == 0 || string(p) == "null" {
+		return nil, nil
+	}
+	t := &struct {
+		Type string `json:"type"`
+	}{}
+	if err := json.Unmarshal(p, t); err != nil {
+		return nil, err
+	}
+	e := newElement(t.Type)
+	if e == nil {
+		return &UnknownElement{Type: t.Type, Raw: append(json.RawMessage(nil), p...)}, nil
+	}
+	if err := json.Unmarshal(p, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// BlockElements is a list of elements which is decoded based on the type of every element
+type BlockElements []BlockElement
+
+// UnmarshalJSON decodes every element to its matching type
+func (elements *BlockElements) UnmarshalJSON(p []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(p, &raw); err != nil {
+		return err
+	}
+	result := make(BlockElements, 0, len(raw))
+	for _, r := range raw {
+		e, err := decodeElement(r)
+		if err != nil {
+			return err
+		}
+		result = append(result, e)
+	}
+	*elements = result
+	return nil
+}
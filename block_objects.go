@@ -0,0 +1,159 @@
+package slack
+
+// Text object types
+const (
+	PlainTextType = "plain_text"
+	MarkdownType  = "mrkdwn"
+)
+
+// TextObject is a plain text or mrkdwn text - see https://api.slack.com/reference/block-kit/composition-objects#text
+type TextObject struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Emoji    bool   `json:"emoji,omitempty"`
+	Verbatim bool   `json:"verbatim,omitempty"`
+}
+
+// NewPlainText creates a plain text object
+func NewPlainText(text string) *TextObject {
+	return &TextObject{Type: PlainTextType, Text: text}
+}
+
+// NewMarkdown creates a mrkdwn text object
+func NewMarkdown(text string) *TextObject {
+	return &TextObject{Type: MarkdownType, Text: text}
+}
+
+// ElementType returns the type of the text so it can be used as a context element
+func (t *TextObject) ElementType() string {
+	return t.Type
+}
+
+// validate the text object with the given maximum length
+func (t *TextObject) validate(field string, maxLen int, plainOnly bool) error {
+	if t == nil {
+		return nil
+	}
+	if t.Type != PlainTextType && t.Type != MarkdownType {
+		return newError("invalid_blocks", "%s has invalid text type [%s]", field, t.Type)
+	}
+	if plainOnly && t.Type != PlainTextType {
+		return newError("invalid_blocks", "%s must be plain_text", field)
+	}
+	if t.Text == "" {
+		return newError("invalid_blocks", "%s must not be empty", field)
+	}
+	if maxLen > 0 && len([]rune(t.Text)) > maxLen {
+		return newError("invalid_blocks", "%s is longer than %d characters", field, maxLen)
+	}
+	return nil
+}
+
+// Validate the text object as a context element
+func (t *TextObject) Validate() error {
+	return t.validate("text", 3000, false)
+}
+
+// OptionObject is an option of a select, overflow, checkboxes or radio buttons element
+type OptionObject struct {
+	Text        *TextObject `json:"text"`
+	Value       string      `json:"value"`
+	Description *TextObject `json:"description,omitempty"`
+	URL         string      `json:"url,omitempty"`
+}
+
+// NewOption creates an option with plain text
+func NewOption(text, value string) *OptionObject {
+	return &OptionObject{Text: NewPlainText(text), Value: value}
+}
+
+// Validate the option
+func (o *OptionObject) Validate() error {
+	if o.Text == nil {
+		return newError("invalid_blocks", "option text is required")
+	}
+	if err := o.Text.validate("option text", 75, false); err != nil {
+		return err
+	}
+	if err := maxLength("option value", o.Value, 150); err != nil {
+		return err
+	}
+	return o.Description.validate("option description", 75, false)
+}
+
+// OptionGroupObject groups options in a select menu
+type OptionGroupObject struct {
+	Label   *TextObject     `json:"label"`
+	Options []*OptionObject `json:"options"`
+}
+
+// Validate the option group
+func (g *OptionGroupObject) Validate() error {
+	if g.Label == nil {
+		return newError("invalid_blocks", "option group label is required")
+	}
+	if err := g.Label.validate("option group label", 75, true); err != nil {
+		return err
+	}
+	return validateOptions(g.Options, 100)
+}
+
+// ConfirmationObject is a confirmation dialog shown before an action
+type ConfirmationObject struct {
+	Title   *TextObject `json:"title"`
+	Text    *TextObject `json:"text"`
+	Confirm *TextObject `json:"confirm"`
+	Deny    *TextObject `json:"deny"`
+	Style   string      `json:"style,omitempty"`
+}
+
+// NewConfirmation creates a confirmation dialog with plain text
+func NewConfirmation(title, text, confirm, deny string) *ConfirmationObject {
+	return &ConfirmationObject{
+		Title:   NewPlainText(title),
+		Text:    NewPlainText(text),
+		Confirm: NewPlainText(confirm),
+		Deny:    NewPlainText(deny),
+	}
+}
+
+// Validate the confirmation
+func (c *ConfirmationObject) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.Title == nil || c.Text == nil || c.Confirm == nil || c.Deny == nil {
+		return newError("invalid_blocks", "confirmation requires title, text, confirm and deny")
+	}
+	if err := c.Title.validate("confirmation title", 100, true); err != nil {
+		return err
+	}
+	if err := c.Text.validate("confirmation text", 300, false); err != nil {
+		return err
+	}
+	if err := c.Confirm.validate("confirmation confirm", 30, true); err != nil {
+		return err
+	}
+	return c.Deny.validate("confirmation deny", 30, true)
+}
+
+// validateOptions checks the number of options and every option
+func validateOptions(options []*OptionObject, max int) error {
+	if len(options) > max {
+		return newError("invalid_blocks", "more than %d options", max)
+	}
+	for _, o := range options {
+		if err := o.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxLength checks the length of a string field
+func maxLength(field, value string, max int) error {
+	if len([]rune(value)) > max {
+		return newError("invalid_blocks", "%s is longer than %d characters", field, max)
+	}
+	return nil
+}
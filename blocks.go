@@ -0,0 +1,476 @@
+package slack
+
+import (
+	"encoding/json"
+)
+
+// Block types - see https://api.slack.com/reference/block-kit/blocks
+const (
+	BlockSection = "section"
+	BlockDivider = "divider"
+	BlockImage   = "image"
+	BlockContext = "context"
+	BlockActions = "actions"
+	BlockInput   = "input"
+	BlockHeader  = "header"
+)
+
+// Block is implemented by all the layout blocks
+type Block interface {
+	BlockType() string
+	Validate() error
+}
+
+// SectionBlock displays text with optional fields and an accessory element
+type SectionBlock struct {
+	Type      string        `json:"type"`
+	BlockID   string        `json:"block_id,omitempty"`
+	Text      *TextObject   `json:"text,omitempty"`
+	Fields    []*TextObject `json:"fields,omitempty"`
+	Accessory BlockElement  `json:"accessory,omitempty"`
+}
+
+// NewSection creates a section block with the given text and optional fields
+func NewSection(text *TextObject, fields ...*TextObject) *SectionBlock {
+	return &SectionBlock{Type: BlockSection, Text: text, Fields: fields}
+}
+
+// BlockType returns the type of the block
+func (b *SectionBlock) BlockType() string {
+	return b.Type
+}
+
+// Validate the section
+func (b *SectionBlock) Validate() error {
+	if b.Text == nil && len(b.Fields) == 0 {
+		return newError("invalid_blocks", "section requires text or fields")
+	}
+	if err := b.Text.validate("section text", 3000, false); err != nil {
+		return err
+	}
+	if len(b.Fields) > 10 {
+		return newError("invalid_blocks", "section has more than 10 fields")
+	}
+	for _, f := range b.Fields {
+		if err := f.validate("section field", 2000, false); err != nil {
+			return err
+		}
+	}
+	if b.Accessory != nil {
+		return b.Accessory.Validate()
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes the accessory based on its type
+func (b *SectionBlock) UnmarshalJSON(p []byte) error {
+	type section SectionBlock
+	aux := &struct {
+		*section
+		Accessory json.RawMessage `json:"accessory,omitempty"`
+	}{section: (*section)(b)}
+	if err := json.Unmarshal(p, aux); err != nil {
+		return err
+	}
+	accessory, err := decodeElement(aux.Accessory)
+	if err != nil {
+		return err
+	}
+	b.Accessory = accessory
+	return nil
+}
+
+// DividerBlock is a visual separator
+type DividerBlock struct {
+	Type    string `json:"type"`
+	BlockID string `json:"block_id,omitempty"`
+}
+
+// NewDivider creates a divider block
+func NewDivider() *DividerBlock {
+	return &DividerBlock{Type: BlockDivider}
+}
+
+// BlockType returns the type of the block
+func (b *DividerBlock) BlockType() string {
+	return b.Type
+}
+
+// Validate the divider
+func (b *DividerBlock) Validate() error {
+	return nil
+}
+
+// ImageBlock displays an image
+type ImageBlock struct {
+	Type     string      `json:"type"`
+	BlockID  string      `json:"block_id,omitempty"`
+	ImageURL string      `json:"image_url"`
+	AltText  string      `json:"alt_text"`
+	Title    *TextObject `json:"title,omitempty"`
+}
+
+// NewImage creates an image block
+func NewImage(imageURL, altText string) *ImageBlock {
+	return &ImageBlock{Type: BlockImage, ImageURL: imageURL, AltText: altText}
+}
+
+// BlockType returns the type of the block
+func (b *ImageBlock) BlockType() string {
+	return b.Type
+}
+
+// Validate the image
+func (b *ImageBlock) Validate() error {
+	if b.ImageURL == "" || b.AltText == "" {
+		return newError("invalid_blocks", "image requires image_url and alt_text")
+	}
+	if err := maxLength("image_url", b.ImageURL, 3000); err != nil {
+		return err
+	}
+	if err := maxLength("alt_text", b.AltText, 2000); err != nil {
+		return err
+	}
+	return b.Title.validate("image title", 2000, true)
+}
+
+// ContextBlock displays text and images as secondary information
+type ContextBlock struct {
+	Type     string        `json:"type"`
+	BlockID  string        `json:"block_id,omitempty"`
+	Elements BlockElements `json:"elements"`
+}
+
+// NewContext creates a context block with text objects and image elements
+func NewContext(elements ...BlockElement) *ContextBlock {
+	return &ContextBlock{Type: BlockContext, Elements: elements}
+}
+
+// BlockType returns the type of the block
+func (b *ContextBlock) BlockType() string {
+	return b.Type
+}
+
+// Validate the context
+func (b *ContextBlock) Validate() error {
+	if len(b.Elements) == 0 || len(b.Elements) > 10 {
+		return newError("invalid_blocks", "context requires 1 to 10 elements")
+	}
+	for _, e := range b.Elements {
+		switch e.(type) {
+		case *TextObject, *ImageElement:
+		default:
+			return newError("invalid_blocks", "context does not support %s elements", e.ElementType())
+		}
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ActionsBlock holds interactive elements
+type ActionsBlock struct {
+	Type     string        `json:"type"`
+	BlockID  string        `json:"block_id,omitempty"`
+	Elements BlockElements `json:"elements"`
+}
+
+// NewActions creates an actions block
+func NewActions(blockID string, elements ...BlockElement) *ActionsBlock {
+	return &ActionsBlock{Type: BlockActions, BlockID: blockID, Elements: elements}
+}
+
+// BlockType returns the type of the block
+func (b *ActionsBlock) BlockType() string {
+	return b.Type
+}
+
+// Validate the actions
+func (b *ActionsBlock) Validate() error {
+	if len(b.Elements) == 0 || len(b.Elements) > 25 {
+		return newError("invalid_blocks", "actions requires 1 to 25 elements")
+	}
+	for _, e := range b.Elements {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InputBlock collects information from users in modals
+type InputBlock struct {
+	Type     string       `json:"type"`
+	BlockID  string       `json:"block_id,omitempty"`
+	Label    *TextObject  `json:"label"`
+	Element  BlockElement `json:"element"`
+	Hint     *TextObject  `json:"hint,omitempty"`
+	Optional bool         `json:"optional,omitempty"`
+}
+
+// NewInput creates an input block
+func NewInput(blockID, label string, element BlockElement) *InputBlock {
+	return &InputBlock{Type: BlockInput, BlockID: blockID, Label: NewPlainText(label), Element: element}
+}
+
+// BlockType returns the type of the block
+func (b *InputBlock) BlockType() string {
+	return b.Type
+}
+
+// Validate the input
+func (b *InputBlock) Validate() error {
+	if b.Label == nil || b.Element == nil {
+		return newError("invalid_blocks", "input requires label and element")
+	}
+	if err := b.Label.validate("input label", 2000, true); err != nil {
+		return err
+	}
+	if err := b.Hint.validate("input hint", 2000, true); err != nil {
+		return err
+	}
+	return b.Element.Validate()
+}
+
+// UnmarshalJSON decodes the element based on its type
+func (b *InputBlock) UnmarshalJSON(p []byte) error {
+	type input InputBlock
+	aux := &struct {
+		*input
+		Element json.RawMessage `json:"element"`
+	}{input: (*input)(b)}
+	if err := json.Unmarshal(p, aux); err != nil {
+		return err
+	}
+	element, err := decodeElement(aux.Element)
+	if err != nil {
+		return err
+	}
+	b.Element = element
+	return nil
+}
+
+// HeaderBlock displays plain text in a larger font
+type HeaderBlock struct {
+	Type    string      `json:"type"`
+	BlockID string      `json:"block_id,omitempty"`
+	Text    *TextObject `json:"text"`
+}
+
+// NewHeader creates a header block
+func NewHeader(text string) *HeaderBlock {
+	return &HeaderBlock{Type: BlockHeader, Text: NewPlainText(text)}
+}
+
+// BlockType returns the type of the block
+func (b *HeaderBlock) BlockType() string {
+	return b.Type
+}
+
+// Validate the header
+func (b *HeaderBlock) Validate() error {
+	if b.Text == nil {
+		return newError("invalid_blocks", "header text is required")
+	}
+	return b.Text.validate("header text", 150, true)
+}
+
+// UnknownBlock holds a block type the library does not know yet
+type UnknownBlock struct {
+	Type string          `json:"type"`
+	Raw  json.RawMessage `json:"-"`
+}
+
+// BlockType returns the type of the block
+func (b *UnknownBlock) BlockType() string {
+	return b.Type
+}
+
+// Validate does nothing for unknown blocks
+func (b *UnknownBlock) Validate() error {
+	return nil
+}
+
+// MarshalJSON returns the raw block
+func (b *UnknownBlock) MarshalJSON() ([]byte, error) {
+	return b.Raw, nil
+}
+
+// newBlock creates an empty block for the given type
+func newBlock(blockType string) Block {
+	switch blockType {
+	case BlockSection:
+		return &SectionBlock{}
+	case BlockDivider:
+		return &DividerBlock{}
+	case BlockImage:
+		return &ImageBlock{}
+	case BlockContext:
+		return &ContextBlock{}
+	case BlockActions:
+		return &ActionsBlock{}
+	case BlockInput:
+		return &InputBlock{}
+	case BlockHeader:
+		return &HeaderBlock{}
+	}
+	return nil
+}
+
+// blockID returns the block_id of the block
+func blockID(b Block) string {
+	switch b := b.(type) {
+	case *SectionBlock:
+		return b.BlockID
+	case *DividerBlock:
+		return b.BlockID
+	case *ImageBlock:
+		return b.BlockID
+	case *ContextBlock:
+		return b.BlockID
+	case *ActionsBlock:
+		return b.BlockID
+	case *InputBlock:
+		return b.BlockID
+	case *HeaderBlock:
+		return b.BlockID
+	}
+	return ""
+}
+
+// Blocks is a list of blocks which is decoded based on the type of every block
+type Blocks []Block
+
+// Validate checks the blocks against the Block Kit limits before they are sent
+func (blocks Blocks) Validate() error {
+	if len(blocks) > 50 {
+		return newError("invalid_blocks", "more than 50 blocks")
+	}
+	ids := make(map[string]bool)
+	for _, b := range blocks {
+		if b == nil {
+			return newError("invalid_blocks", "nil block")
+		}
+		id := blockID(b)
+		if err := maxLength("block_id", id, 255); err != nil {
+			return err
+		}
+		if id != "" {
+			if ids[id] {
+				return newError("invalid_blocks", "duplicate block_id [%s]", id)
+			}
+			ids[id] = true
+		}
+		if err := b.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes every block to its matching type
+func (blocks *Blocks) UnmarshalJSON(p []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(p, &raw); err != nil {
+		return err
+	}
+	result := make(Blocks, 0, len(raw))
+	for _, r := range raw {
+		t := &struct {
+			Type string `json:"type"`
+		}{}
+		if err := json.Unmarshal(r, t); err != nil {
+			return err
+		}
+		b := newBlock(t.Type)
+		if b == nil {
+			result = append(result, &UnknownBlock{Type: t.Type, Raw: append(json.RawMessage(nil), r...)})
+			continue
+		}
+		if err := json.Unmarshal(r, b); err != nil {
+			return err
+		}
+		result = append(result, b)
+	}
+	*blocks = result
+	return nil
+}
+
+// BlockBuilder builds a list of blocks
+//
+// Example:
+//
+//	blocks, err := slack.NewBlockBuilder().
+//		Header("Deploy").
+//		Markdown("*Service* is ready").
+//		Divider().
+//		Actions("deploy", slack.NewButton("approve", "Approve", "yes")).
+//		Build()
+type BlockBuilder struct {
+	blocks Blocks
+}
+
+// NewBlockBuilder creates an empty builder
+func NewBlockBuilder() *BlockBuilder {
+	return &BlockBuilder{}
+}
+
+// Add appends the given blocks
+func (b *BlockBuilder) Add(blocks ...Block) *BlockBuilder {
+	b.blocks = append(b.blocks, blocks...)
+	return b
+}
+
+// Section appends a section with the given text and optional fields
+func (b *BlockBuilder) Section(text *TextObject, fields ...*TextObject) *BlockBuilder {
+	return b.Add(NewSection(text, fields...))
+}
+
+// Markdown appends a section with mrkdwn text
+func (b *BlockBuilder) Markdown(text string) *BlockBuilder {
+	return b.Add(NewSection(NewMarkdown(text)))
+}
+
+// Header appends a header
+func (b *BlockBuilder) Header(text string) *BlockBuilder {
+	return b.Add(NewHeader(text))
+}
+
+// Divider appends a divider
+func (b *BlockBuilder) Divider() *BlockBuilder {
+	return b.Add(NewDivider())
+}
+
+// Context appends a context with the given elements
+func (b *BlockBuilder) Context(elements ...BlockElement) *BlockBuilder {
+	return b.Add(NewContext(elements...))
+}
+
+// Actions appends an actions block with the given elements
+func (b *BlockBuilder) Actions(blockID string, elements ...BlockElement) *BlockBuilder {
+	return b.Add(NewActions(blockID, elements...))
+}
+
+// Image appends an image
+func (b *BlockBuilder) Image(imageURL, altText string) *BlockBuilder {
+	return b.Add(NewImage(imageURL, altText))
+}
+
+// Input appends an input with the given element
+func (b *BlockBuilder) Input(blockID, label string, element BlockElement) *BlockBuilder {
+	return b.Add(NewInput(blockID, label, element))
+}
+
+// Blocks returns the blocks without validating them
+func (b *BlockBuilder) Blocks() Blocks {
+	return b.blocks
+}
+
+// Build validates and returns the blocks
+func (b *BlockBuilder) Build() (Blocks, error) {
+	if err := b.blocks.Validate(); err != nil {
+		return nil, err
+	}
+	return b.blocks, nil
+}
@@ -1,6 +1,7 @@
 package slack
 
 import (
+	"context"
 	"net/url"
 	"strconv"
 	"strings"
@@ -24,7 +25,7 @@ type BaseChannel struct {
 	Members            []string            `json:"members"`
 	Topic              ChannelTopicPurpose `json:"topic"`
 	Purpose            ChannelTopicPurpose `json:"purpose"`
-	LastRead           string              `json:"last_read,omitempty"`
+	LastRead           Timestamp           `json:"last_read,omitempty"`
 	Latest             Message             `json:"latest,omitempty"`
 	UnreadCount        int                 `json:"unread_count,omitempty"`
 	UnreadCountDisplay int                 `json:"unread_count_display,omitempty"`
@@ -75,9 +76,9 @@ type ChannelCommonResponse struct {
 // HistoryResponse holds a response to a history request
 type HistoryResponse struct {
 	slackResponse
-	Latest             string    `json:"latest"`
+	Latest             Timestamp `json:"latest"` // Not sent by conversations.history so always empty
 	HasMore            bool      `json:"has_more"`
-	UnreadCountDisplay int       `json:"unread_count_display"`
+	UnreadCountDisplay int       `json:"unread_count_display"` // Not sent by conversations.history so always 0
 	Messages           []Message `json:"messages"`
 }
 
@@ -139,22 +140,16 @@ type CloseResponse struct {
 	AlreadyClosed bool `json:"already_closed"`
 }
 
-func prefixByID(id string) string {
-	path := "channels."
-	switch id[0] {
-	case 'G':
-		path = "groups."
-	case 'D':
-		path = "im."
-	}
-	return path
-}
-
 // Archive a channel or a group
 func (s *Slack) Archive(channel string) (Response, error) {
+	return s.ArchiveContext(context.Background(), channel)
+}
+
+// ArchiveContext is Archive with a custom context
+func (s *Slack) ArchiveContext(ctx context.Context, channel string) (Response, error) {
 	params := url.Values{"channel": {channel}}
 	r := &slackResponse{}
-	err := s.do(prefixByID(channel)+"archive", params, r)
+	err := s.do(ctx, "conversations.archive", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -163,31 +158,42 @@ func (s *Slack) Archive(channel string) (Response, error) {
 
 // Unarchive a channel or a group
 func (s *Slack) Unarchive(channel string) (Response, error) {
+	return s.UnarchiveContext(context.Background(), channel)
+}
+
+// UnarchiveContext is Unarchive with a custom context
+func (s *Slack) UnarchiveContext(ctx context.Context, channel string) (Response, error) {
 	params := url.Values{"channel": {channel}}
 	r := &slackResponse{}
-	err := s.do(prefixByID(channel)+"unarchive", params, r)
+	err := s.do(ctx, "conversations.unarchive", params, r)
 	if err != nil {
 		return nil, err
 	}
 	return r, nil
 }
 
-// History retrieves history of channel, group, MPIM and IM
-func (s *Slack) History(channel, latest, oldest string, inclusive, unreads bool, count int) (*HistoryResponse, error) {
+// History retrieves history of channel, group, MPIM and IM.
+// The unreads flag is not supported by conversations.history and is ignored so the
+// Latest and UnreadCountDisplay fields of the response are never set.
+//
+// Deprecated: use ConversationHistory or HistoryIter which also page through the history.
+func (s *Slack) History(channel string, latest, oldest Timestamp, inclusive, unreads bool, count int) (*HistoryResponse, error) {
+	return s.HistoryContext(context.Background(), channel, latest, oldest, inclusive, unreads, count)
+}
+
+// HistoryContext is History with a custom context
+func (s *Slack) HistoryContext(ctx context.Context, channel string, latest, oldest Timestamp, inclusive, unreads bool, count int) (*HistoryResponse, error) {
 	params := url.Values{"channel": {channel}}
-	appendNotEmpty("latest", latest, params)
-	appendNotEmpty("oldest", oldest, params)
+	appendNotEmpty("latest", string(latest), params)
+	appendNotEmpty("oldest", string(oldest), params)
 	if inclusive {
 		params.Set("inclusive", "1")
 	}
-	if unreads {
-		params.Set("unreads", "1")
-	}
 	if count != 0 {
-		params.Set("count", strconv.Itoa(count))
+		params.Set("limit", strconv.Itoa(count))
 	}
 	r := &HistoryResponse{}
-	err := s.do(prefixByID(channel)+"history", params, r)
+	err := s.do(ctx, "conversations.history", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -196,9 +202,14 @@ func (s *Slack) History(channel, latest, oldest string, inclusive, unreads bool,
 
 // Kick a user from a channel or group
 func (s *Slack) Kick(channel, user string) (Response, error) {
+	return s.KickContext(context.Background(), channel, user)
+}
+
+// KickContext is Kick with a custom context
+func (s *Slack) KickContext(ctx context.Context, channel, user string) (Response, error) {
 	params := url.Values{"channel": {channel}, "user": {user}}
 	r := &slackResponse{}
-	err := s.do(prefixByID(channel)+"kick", params, r)
+	err := s.do(ctx, "conversations.kick", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -207,21 +218,30 @@ func (s *Slack) Kick(channel, user string) (Response, error) {
 
 // Leave a channel or a group
 func (s *Slack) Leave(channel string) (Response, error) {
+	return s.LeaveContext(context.Background(), channel)
+}
+
+// LeaveContext is Leave with a custom context
+func (s *Slack) LeaveContext(ctx context.Context, channel string) (Response, error) {
 	params := url.Values{"channel": {channel}}
 	r := &slackResponse{}
-	err := s.do(prefixByID(channel)+"join", params, r)
+	err := s.do(ctx, "conversations.leave", params, r)
 	if err != nil {
 		return nil, err
 	}
 	return r, nil
 }
 
-// Mark marks the given channel as read. Automatically detects channel/group/im
-func (s *Slack) Mark(channel, ts string) error {
+// Mark marks the given channel, group or IM as read
+func (s *Slack) Mark(channel string, ts Timestamp) error {
+	return s.MarkContext(context.Background(), channel, ts)
+}
+
+// MarkContext is Mark with a custom context
+func (s *Slack) MarkContext(ctx context.Context, channel string, ts Timestamp) error {
 	r := &slackResponse{}
-	params := url.Values{"channel": {channel}, "ts": {ts}}
-	path := prefixByID(channel) + "mark"
-	err := s.do(path, params, r)
+	params := url.Values{"channel": {channel}, "ts": {string(ts)}}
+	err := s.do(ctx, "conversations.mark", params, r)
 	if err != nil {
 		return err
 	}
@@ -230,9 +250,14 @@ func (s *Slack) Mark(channel, ts string) error {
 
 // Rename a channel or a group
 func (s *Slack) Rename(channel, name string) (*ChannelCommonResponse, error) {
+	return s.RenameContext(context.Background(), channel, name)
+}
+
+// RenameContext is Rename with a custom context
+func (s *Slack) RenameContext(ctx context.Context, channel, name string) (*ChannelCommonResponse, error) {
 	params := url.Values{"channel": {channel}, "name": {name}}
 	r := &ChannelCommonResponse{}
-	err := s.do(prefixByID(channel)+"rename", params, r)
+	err := s.do(ctx, "conversations.rename", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -241,31 +266,42 @@ func (s *Slack) Rename(channel, name string) (*ChannelCommonResponse, error) {
 
 // SetPurpose of the channel / group
 func (s *Slack) SetPurpose(channel, purpose string) (*PurposeResponse, error) {
-	params := url.Values{"channel": {channel}, "purpose": {purpose}}
-	r := &PurposeResponse{}
-	err := s.do(prefixByID(channel)+"setPurpose", params, r)
+	return s.SetPurposeContext(context.Background(), channel, purpose)
+}
+
+// SetPurposeContext is SetPurpose with a custom context
+func (s *Slack) SetPurposeContext(ctx context.Context, channel, purpose string) (*PurposeResponse, error) {
+	r, err := s.ConversationSetPurposeContext(ctx, channel, purpose)
 	if err != nil {
 		return nil, err
 	}
-	return r, nil
+	return &PurposeResponse{slackResponse: r.slackResponse, Purpose: r.Channel.Purpose.Value}, nil
 }
 
 // SetTopic of the channel / group
 func (s *Slack) SetTopic(channel, purpose string) (*TopicResponse, error) {
-	params := url.Values{"channel": {channel}, "topic": {purpose}}
-	r := &TopicResponse{}
-	err := s.do(prefixByID(channel)+"setTopic", params, r)
+	return s.SetTopicContext(context.Background(), channel, purpose)
+}
+
+// SetTopicContext is SetTopic with a custom context
+func (s *Slack) SetTopicContext(ctx context.Context, channel, purpose string) (*TopicResponse, error) {
+	r, err := s.ConversationSetTopicContext(ctx, channel, purpose)
 	if err != nil {
 		return nil, err
 	}
-	return r, nil
+	return &TopicResponse{slackResponse: r.slackResponse, Topic: r.Channel.Topic.Value}, nil
 }
 
 // CloseGroupOrIM closes the given id
 func (s *Slack) CloseGroupOrIM(id string) (*CloseResponse, error) {
+	return s.CloseGroupOrIMContext(context.Background(), id)
+}
+
+// CloseGroupOrIMContext is CloseGroupOrIM with a custom context
+func (s *Slack) CloseGroupOrIMContext(ctx context.Context, id string) (*CloseResponse, error) {
 	params := url.Values{"channel": {id}}
 	r := &CloseResponse{}
-	err := s.do(prefixByID(id)+"close", params, r)
+	err := s.do(ctx, "conversations.close", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -274,9 +310,14 @@ func (s *Slack) CloseGroupOrIM(id string) (*CloseResponse, error) {
 
 // OpenGroup opens a group
 func (s *Slack) OpenGroup(id string) (*OpenResponse, error) {
+	return s.OpenGroupContext(context.Background(), id)
+}
+
+// OpenGroupContext is OpenGroup with a custom context
+func (s *Slack) OpenGroupContext(ctx context.Context, id string) (*OpenResponse, error) {
 	params := url.Values{"channel": {id}}
 	r := &OpenResponse{}
-	err := s.do(prefixByID(id)+"open", params, r)
+	err := s.do(ctx, "conversations.open", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -285,9 +326,14 @@ func (s *Slack) OpenGroup(id string) (*OpenResponse, error) {
 
 // OpenIM opens an IM conversation with the given user ID
 func (s *Slack) OpenIM(id string) (*OpenIMResponse, error) {
+	return s.OpenIMContext(context.Background(), id)
+}
+
+// OpenIMContext is OpenIM with a custom context
+func (s *Slack) OpenIMContext(ctx context.Context, id string) (*OpenIMResponse, error) {
 	params := url.Values{"user": {id}, "return_im": {"true"}}
 	r := &OpenIMResponse{}
-	err := s.do("im.open", params, r)
+	err := s.do(ctx, "im.open", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -296,9 +342,14 @@ func (s *Slack) OpenIM(id string) (*OpenIMResponse, error) {
 
 // OpenMPIM with the given users
 func (s *Slack) OpenMPIM(users []string) (*GroupResponse, error) {
+	return s.OpenMPIMContext(context.Background(), users)
+}
+
+// OpenMPIMContext is OpenMPIM with a custom context
+func (s *Slack) OpenMPIMContext(ctx context.Context, users []string) (*GroupResponse, error) {
 	params := url.Values{"users": {strings.Join(users, ",")}}
 	r := &GroupResponse{}
-	err := s.do("mpim.open", params, r)
+	err := s.do(ctx, "mpim.open", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -307,9 +358,14 @@ func (s *Slack) OpenMPIM(users []string) (*GroupResponse, error) {
 
 // ChannelCreate creates a channel
 func (s *Slack) ChannelCreate(name string) (*ChannelResponse, error) {
+	return s.ChannelCreateContext(context.Background(), name)
+}
+
+// ChannelCreateContext is ChannelCreate with a custom context
+func (s *Slack) ChannelCreateContext(ctx context.Context, name string) (*ChannelResponse, error) {
 	params := url.Values{"name": {name}}
 	r := &ChannelResponse{}
-	err := s.do("channels.create", params, r)
+	err := s.do(ctx, "channels.create", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -318,9 +374,14 @@ func (s *Slack) ChannelCreate(name string) (*ChannelResponse, error) {
 
 // ChannelInvite invites a user to a group
 func (s *Slack) ChannelInvite(channel, user string) (*ChannelResponse, error) {
+	return s.ChannelInviteContext(context.Background(), channel, user)
+}
+
+// ChannelInviteContext is ChannelInvite with a custom context
+func (s *Slack) ChannelInviteContext(ctx context.Context, channel, user string) (*ChannelResponse, error) {
 	params := url.Values{"channel": {channel}, "user": {user}}
 	r := &ChannelResponse{}
-	err := s.do("channels.invite", params, r)
+	err := s.do(ctx, "channels.invite", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -329,9 +390,14 @@ func (s *Slack) ChannelInvite(channel, user string) (*ChannelResponse, error) {
 
 // ChannelInfo returns info about the channel
 func (s *Slack) ChannelInfo(channel string) (*ChannelResponse, error) {
+	return s.ChannelInfoContext(context.Background(), channel)
+}
+
+// ChannelInfoContext is ChannelInfo with a custom context
+func (s *Slack) ChannelInfoContext(ctx context.Context, channel string) (*ChannelResponse, error) {
 	params := url.Values{"channel": {channel}}
 	r := &ChannelResponse{}
-	err := s.do("channels.info", params, r)
+	err := s.do(ctx, "channels.info", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -339,13 +405,20 @@ func (s *Slack) ChannelInfo(channel string) (*ChannelResponse, error) {
 }
 
 // ChannelList returns the list of channels
+//
+// Deprecated: channels.list is retired by Slack, use ConversationList or ConversationIter.
 func (s *Slack) ChannelList(excludeArchived bool) (*ChannelListResponse, error) {
+	return s.ChannelListContext(context.Background(), excludeArchived)
+}
+
+// ChannelListContext is ChannelList with a custom context
+func (s *Slack) ChannelListContext(ctx context.Context, excludeArchived bool) (*ChannelListResponse, error) {
 	params := url.Values{}
 	if excludeArchived {
 		params.Set("exclude_archived", "1")
 	}
 	r := &ChannelListResponse{}
-	err := s.do("channels.list", params, r)
+	err := s.do(ctx, "channels.list", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -354,9 +427,14 @@ func (s *Slack) ChannelList(excludeArchived bool) (*ChannelListResponse, error)
 
 // ChannelJoin joins a channel - notice that this expects channel name and not id
 func (s *Slack) ChannelJoin(channel string) (*ChannelResponse, error) {
+	return s.ChannelJoinContext(context.Background(), channel)
+}
+
+// ChannelJoinContext is ChannelJoin with a custom context
+func (s *Slack) ChannelJoinContext(ctx context.Context, channel string) (*ChannelResponse, error) {
 	params := url.Values{"name": {channel}}
 	r := &ChannelResponse{}
-	err := s.do("channels.join", params, r)
+	err := s.do(ctx, "channels.join", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -365,9 +443,14 @@ func (s *Slack) ChannelJoin(channel string) (*ChannelResponse, error) {
 
 // GroupCreate creates a new group with the given name
 func (s *Slack) GroupCreate(name string) (*GroupResponse, error) {
+	return s.GroupCreateContext(context.Background(), name)
+}
+
+// GroupCreateContext is GroupCreate with a custom context
+func (s *Slack) GroupCreateContext(ctx context.Context, name string) (*GroupResponse, error) {
 	params := url.Values{"name": {name}}
 	r := &GroupResponse{}
-	err := s.do("groups.create", params, r)
+	err := s.do(ctx, "groups.create", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -376,9 +459,14 @@ func (s *Slack) GroupCreate(name string) (*GroupResponse, error) {
 
 // GroupCreateChild archives existing group and creates a new group with the given name
 func (s *Slack) GroupCreateChild(group string) (*GroupResponse, error) {
+	return s.GroupCreateChildContext(context.Background(), group)
+}
+
+// GroupCreateChildContext is GroupCreateChild with a custom context
+func (s *Slack) GroupCreateChildContext(ctx context.Context, group string) (*GroupResponse, error) {
 	params := url.Values{"channel": {group}}
 	r := &GroupResponse{}
-	err := s.do("groups.createChild", params, r)
+	err := s.do(ctx, "groups.createChild", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -387,9 +475,14 @@ func (s *Slack) GroupCreateChild(group string) (*GroupResponse, error) {
 
 // GroupInfo returns info about the group
 func (s *Slack) GroupInfo(group string) (*GroupResponse, error) {
+	return s.GroupInfoContext(context.Background(), group)
+}
+
+// GroupInfoContext is GroupInfo with a custom context
+func (s *Slack) GroupInfoContext(ctx context.Context, group string) (*GroupResponse, error) {
 	params := url.Values{"channel": {group}}
 	r := &GroupResponse{}
-	err := s.do("groups.info", params, r)
+	err := s.do(ctx, "groups.info", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -398,9 +491,14 @@ func (s *Slack) GroupInfo(group string) (*GroupResponse, error) {
 
 // GroupInvite invites a user to a group
 func (s *Slack) GroupInvite(channel, user string) (*GroupResponse, error) {
+	return s.GroupInviteContext(context.Background(), channel, user)
+}
+
+// GroupInviteContext is GroupInvite with a custom context
+func (s *Slack) GroupInviteContext(ctx context.Context, channel, user string) (*GroupResponse, error) {
 	params := url.Values{"channel": {channel}, "user": {user}}
 	r := &GroupResponse{}
-	err := s.do("groups.invite", params, r)
+	err := s.do(ctx, "groups.invite", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -408,13 +506,20 @@ func (s *Slack) GroupInvite(channel, user string) (*GroupResponse, error) {
 }
 
 // GroupList returns the list of groups
+//
+// Deprecated: groups.list is retired by Slack, use ConversationList or ConversationIter.
 func (s *Slack) GroupList(excludeArchived bool) (*GroupListResponse, error) {
+	return s.GroupListContext(context.Background(), excludeArchived)
+}
+
+// GroupListContext is GroupList with a custom context
+func (s *Slack) GroupListContext(ctx context.Context, excludeArchived bool) (*GroupListResponse, error) {
 	params := url.Values{}
 	if excludeArchived {
 		params.Set("exclude_archived", "1")
 	}
 	r := &GroupListResponse{}
-	err := s.do("groups.list", params, r)
+	err := s.do(ctx, "groups.list", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -422,10 +527,17 @@ func (s *Slack) GroupList(excludeArchived bool) (*GroupListResponse, error) {
 }
 
 // MPIMList returns the list of MPIMs
+//
+// Deprecated: mpim.list is retired by Slack, use ConversationList or ConversationIter.
 func (s *Slack) MPIMList() (*GroupListResponse, error) {
+	return s.MPIMListContext(context.Background())
+}
+
+// MPIMListContext is MPIMList with a custom context
+func (s *Slack) MPIMListContext(ctx context.Context) (*GroupListResponse, error) {
 	params := url.Values{}
 	r := &GroupListResponse{}
-	err := s.do("mpim.list", params, r)
+	err := s.do(ctx, "mpim.list", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -433,10 +545,17 @@ func (s *Slack) MPIMList() (*GroupListResponse, error) {
 }
 
 // IMList returns the list of IMs
+//
+// Deprecated: im.list is retired by Slack, use ConversationList or ConversationIter.
 func (s *Slack) IMList() (*IMListResponse, error) {
+	return s.IMListContext(context.Background())
+}
+
+// IMListContext is IMList with a custom context
+func (s *Slack) IMListContext(ctx context.Context) (*IMListResponse, error) {
 	params := url.Values{}
 	r := &IMListResponse{}
-	err := s.do("im.list", params, r)
+	err := s.do(ctx, "im.list", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -1,10 +1,12 @@
 package slack
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // AttachmentField holds information about an attachment field
@@ -37,49 +39,77 @@ type Attachment struct {
 
 // PostMessageRequest includes all the fields in the post message request - see https://api.slack.com/methods/chat.postMessage
 type PostMessageRequest struct {
-	Channel     string       `json:"channel"`
-	Text        string       `json:"text"`
-	Username    string       `json:"username"`
-	AsUser      bool         `json:"as_user"`
-	Parse       string       `json:"parse"`
-	LinkNames   int          `json:"link_names"`
-	Attachments []Attachment `json:"attachments"`
-	UnfurlLinks bool         `json:"unfurl_links"`
-	UnfurlMedia bool         `json:"unfurl_media"`
-	IconURL     string       `json:"icon_url"`
-	IconEmoji   string       `json:"icon_emoji"`
-	ThreadID    string       `json:"thread_ts"`
+	Channel        string       `json:"channel"`
+	Text           string       `json:"text"`
+	Username       string       `json:"username"`
+	AsUser         bool         `json:"as_user"`
+	Parse          string       `json:"parse"`
+	LinkNames      int          `json:"link_names"`
+	Attachments    []Attachment `json:"attachments"`
+	Blocks         Blocks       `json:"blocks,omitempty"`
+	UnfurlLinks    bool         `json:"unfurl_links"`
+	UnfurlMedia    bool         `json:"unfurl_media"`
+	IconURL        string       `json:"icon_url"`
+	IconEmoji      string       `json:"icon_emoji"`
+	ThreadID       Timestamp    `json:"thread_ts"`
+	ReplyBroadcast bool         `json:"reply_broadcast,omitempty"` // Also show the thread reply in the channel
 }
 
 // PostMessageReply is the reply to the post message request - see https://api.slack.com/methods/chat.postMessage
 type PostMessageReply struct {
 	slackResponse
 	Channel   string             `json:"channel"`
-	Timestamp string             `json:"ts"`
+	Timestamp Timestamp          `json:"ts"`
 	Message   PostMessageRequest `json:"message"`
 }
 
 // PostMessage posts a message to a channel
 func (s *Slack) PostMessage(m *PostMessageRequest, escape bool) (*PostMessageReply, error) {
-	// Escape the special chars
-	text := ""
+	return s.PostMessageContext(context.Background(), m, escape)
+}
+
+// PostMessageContext is PostMessage with a custom context
+func (s *Slack) PostMessageContext(ctx context.Context, m *PostMessageRequest, escape bool) (*PostMessageReply, error) {
+	params, err := messageParams(m, escape)
+	if err != nil {
+		return nil, err
+	}
+	if m.Username != "" {
+		params.Set("username", m.Username)
+	}
+	params.Set("thread_ts", string(m.ThreadID))
+	if m.ThreadID != "" && m.ReplyBroadcast {
+		params.Set("reply_broadcast", "true")
+	}
+	params.Set("unfurl_links", strconv.FormatBool(m.UnfurlLinks))
+	params.Set("unfurl_media", strconv.FormatBool(m.UnfurlMedia))
+	if m.IconURL != "" {
+		params.Set("icon_url", m.IconURL)
+	}
+	if m.IconEmoji != "" {
+		params.Set("icon_emoji", m.IconEmoji)
+	}
+	r := &PostMessageReply{}
+	err = s.do(ctx, "chat.postMessage", params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// messageParams returns the parameters common to posting and updating a message
+func messageParams(m *PostMessageRequest, escape bool) (url.Values, error) {
+	text := m.Text
 	if escape {
-		replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
-		text = replacer.Replace(m.Text)
-	} else {
-		text = m.Text
+		text = escapeText(text)
 	}
 	params := url.Values{
 		"channel": {m.Channel},
 		"text":    {text},
 	}
-	if m.Username != "" {
-		params.Set("username", m.Username)
-	}
 	params.Set("as_user", strconv.FormatBool(m.AsUser))
 	params.Set("parse", m.Parse)
 	params.Set("link_names", strconv.Itoa(m.LinkNames))
-	params.Set("thread_ts", m.ThreadID)
 	if len(m.Attachments) > 0 {
 		attachments, err := json.Marshal(m.Attachments)
 		if err != nil {
@@ -87,16 +117,205 @@ func (s *Slack) PostMessage(m *PostMessageRequest, escape bool) (*PostMessageRep
 		}
 		params.Set("attachments", string(attachments))
 	}
+	if len(m.Blocks) > 0 {
+		if err := m.Blocks.Validate(); err != nil {
+			return nil, err
+		}
+		blocks, err := json.Marshal(m.Blocks)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("blocks", string(blocks))
+	}
+	return params, nil
+}
+
+// escapeText escapes the special chars
+func escapeText(text string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	return replacer.Replace(text)
+}
+
+// UpdateMessageReply is the reply to the update message request - see https://api.slack.com/methods/chat.update
+type UpdateMessageReply struct {
+	slackResponse
+	Channel   string    `json:"channel"`
+	Timestamp Timestamp `json:"ts"`
+	Text      string    `json:"text"`
+}
+
+// UpdateMessage updates the message with the given timestamp in m.Channel. The text, attachments
+// and blocks of the message are replaced with the ones in m.
+func (s *Slack) UpdateMessage(timestamp Timestamp, m *PostMessageRequest, escape bool) (*UpdateMessageReply, error) {
+	return s.UpdateMessageContext(context.Background(), timestamp, m, escape)
+}
+
+// UpdateMessageContext is UpdateMessage with a custom context
+func (s *Slack) UpdateMessageContext(ctx context.Context, timestamp Timestamp, m *PostMessageRequest, escape bool) (*UpdateMessageReply, error) {
+	params, err := messageParams(m, escape)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("ts", string(timestamp))
+	r := &UpdateMessageReply{}
+	err = s.do(ctx, "chat.update", params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DeleteMessageReply is the reply to the delete message request - see https://api.slack.com/methods/chat.delete
+type DeleteMessageReply struct {
+	slackResponse
+	Channel   string    `json:"channel"`
+	Timestamp Timestamp `json:"ts"`
+}
+
+// DeleteMessage deletes the message with the given timestamp from the channel
+func (s *Slack) DeleteMessage(channel string, timestamp Timestamp) (*DeleteMessageReply, error) {
+	return s.DeleteMessageContext(context.Background(), channel, timestamp)
+}
+
+// DeleteMessageContext is DeleteMessage with a custom context
+func (s *Slack) DeleteMessageContext(ctx context.Context, channel string, timestamp Timestamp) (*DeleteMessageReply, error) {
+	params := url.Values{
+		"channel": {channel},
+		"ts":      {string(timestamp)},
+	}
+	r := &DeleteMessageReply{}
+	err := s.do(ctx, "chat.delete", params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// PostEphemeralReply is the reply to the post ephemeral request - see https://api.slack.com/methods/chat.postEphemeral
+type PostEphemeralReply struct {
+	slackResponse
+	MessageTimestamp Timestamp `json:"message_ts"`
+}
+
+// PostEphemeral posts a message to m.Channel which is visible only to the given user
+func (s *Slack) PostEphemeral(user string, m *PostMessageRequest, escape bool) (*PostEphemeralReply, error) {
+	return s.PostEphemeralContext(context.Background(), user, m, escape)
+}
+
+// PostEphemeralContext is PostEphemeral with a custom context
+func (s *Slack) PostEphemeralContext(ctx context.Context, user string, m *PostMessageRequest, escape bool) (*PostEphemeralReply, error) {
+	params, err := messageParams(m, escape)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("user", user)
+	appendNotEmpty("username", m.Username, params)
+	appendNotEmpty("thread_ts", string(m.ThreadID), params)
+	appendNotEmpty("icon_url", m.IconURL, params)
+	appendNotEmpty("icon_emoji", m.IconEmoji, params)
+	r := &PostEphemeralReply{}
+	err = s.do(ctx, "chat.postEphemeral", params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ScheduleMessageReply is the reply to the schedule message request - see https://api.slack.com/methods/chat.scheduleMessage
+type ScheduleMessageReply struct {
+	slackResponse
+	Channel            string             `json:"channel"`
+	ScheduledMessageID string             `json:"scheduled_message_id"`
+	PostAt             int64              `json:"post_at"`
+	Message            PostMessageRequest `json:"message"`
+}
+
+// ScheduleMessage schedules a message to be posted to m.Channel at the given time
+func (s *Slack) ScheduleMessage(m *PostMessageRequest, postAt time.Time, escape bool) (*ScheduleMessageReply, error) {
+	return s.ScheduleMessageContext(context.Background(), m, postAt, escape)
+}
+
+// ScheduleMessageContext is ScheduleMessage with a custom context
+func (s *Slack) ScheduleMessageContext(ctx context.Context, m *PostMessageRequest, postAt time.Time, escape bool) (*ScheduleMessageReply, error) {
+	params, err := messageParams(m, escape)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("post_at", strconv.FormatInt(postAt.Unix(), 10))
+	appendNotEmpty("thread_ts", string(m.ThreadID), params)
 	params.Set("unfurl_links", strconv.FormatBool(m.UnfurlLinks))
 	params.Set("unfurl_media", strconv.FormatBool(m.UnfurlMedia))
-	if m.IconURL != "" {
-		params.Set("icon_url", m.IconURL)
+	r := &ScheduleMessageReply{}
+	err = s.do(ctx, "chat.scheduleMessage", params, r)
+	if err != nil {
+		return nil, err
 	}
-	if m.IconEmoji != "" {
-		params.Set("icon_emoji", m.IconEmoji)
+	return r, nil
+}
+
+// ScheduledMessage is a message waiting to be posted
+type ScheduledMessage struct {
+	ID          string `json:"id"`
+	Channel     string `json:"channel_id"`
+	PostAt      int64  `json:"post_at"`
+	DateCreated int64  `json:"date_created"`
+	Text        string `json:"text"`
+}
+
+// PostTime returns the time the message will be posted at
+func (m *ScheduledMessage) PostTime() time.Time {
+	return time.Unix(m.PostAt, 0)
+}
+
+// ScheduledMessagesReply is the reply to the scheduled messages list request - see https://api.slack.com/methods/chat.scheduledMessages.list
+type ScheduledMessagesReply struct {
+	slackResponse
+	ScheduledMessages []ScheduledMessage `json:"scheduled_messages"`
+	ResponseMetadata  ResponseMetadata   `json:"response_metadata"`
+}
+
+// ScheduledMessages lists the scheduled messages of the channel (or all channels if empty) which are
+// posted between oldest and latest. Zero times are ignored. Pass the NextCursor of the reply as
+// cursor to get the next page.
+func (s *Slack) ScheduledMessages(channel string, oldest, latest time.Time, cursor string, limit int) (*ScheduledMessagesReply, error) {
+	return s.ScheduledMessagesContext(context.Background(), channel, oldest, latest, cursor, limit)
+}
+
+// ScheduledMessagesContext is ScheduledMessages with a custom context
+func (s *Slack) ScheduledMessagesContext(ctx context.Context, channel string, oldest, latest time.Time, cursor string, limit int) (*ScheduledMessagesReply, error) {
+	params := url.Values{}
+	appendNotEmpty("channel", channel, params)
+	if !oldest.IsZero() {
+		params.Set("oldest", strconv.FormatInt(oldest.Unix(), 10))
 	}
-	r := &PostMessageReply{}
-	err := s.do("chat.postMessage", params, r)
+	if !latest.IsZero() {
+		params.Set("latest", strconv.FormatInt(latest.Unix(), 10))
+	}
+	appendNotEmpty("cursor", cursor, params)
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	r := &ScheduledMessagesReply{}
+	err := s.do(ctx, "chat.scheduledMessages.list", params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DeleteScheduledMessage deletes a scheduled message before it is posted
+func (s *Slack) DeleteScheduledMessage(channel, scheduledMessageID string) (Response, error) {
+	return s.DeleteScheduledMessageContext(context.Background(), channel, scheduledMessageID)
+}
+
+// DeleteScheduledMessageContext is DeleteScheduledMessage with a custom context
+func (s *Slack) DeleteScheduledMessageContext(ctx context.Context, channel, scheduledMessageID string) (Response, error) {
+	params := url.Values{
+		"channel":              {channel},
+		"scheduled_message_id": {scheduledMessageID},
+	}
+	r := &slackResponse{}
+	err := s.do(ctx, "chat.deleteScheduledMessage", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,363 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Conversation types used to filter ConversationList
+const (
+	ConversationPublic  = "public_channel"
+	ConversationPrivate = "private_channel"
+	ConversationMPIM    = "mpim"
+	ConversationIM      = "im"
+)
+
+// Conversation holds information about a public or private channel, MPIM or IM - see https://api.slack.com/types/conversation
+type Conversation struct {
+	BaseChannel
+	NameNormalized     string   `json:"name_normalized,omitempty"`
+	IsChannel          bool     `json:"is_channel"`
+	IsGroup            bool     `json:"is_group"`
+	IsIM               bool     `json:"is_im"`
+	IsMPIM             bool     `json:"is_mpim"`
+	IsPrivate          bool     `json:"is_private"`
+	IsGeneral          bool     `json:"is_general"`
+	IsMember           bool     `json:"is_member"`
+	IsShared           bool     `json:"is_shared"`
+	IsExtShared        bool     `json:"is_ext_shared"`
+	IsOrgShared        bool     `json:"is_org_shared"`
+	IsPendingExtShared bool     `json:"is_pending_ext_shared"`
+	SharedTeamIDs      []string `json:"shared_team_ids,omitempty"`
+	ContextTeamID      string   `json:"context_team_id,omitempty"`
+	User               string   `json:"user,omitempty"` // The other user of an IM
+	IsUserDeleted      bool     `json:"is_user_deleted,omitempty"`
+	Locale             string   `json:"locale,omitempty"`
+}
+
+// ResponseMetadata holds the cursor of the next page of paginated responses
+type ResponseMetadata struct {
+	NextCursor string `json:"next_cursor"`
+}
+
+// ConversationResponse holds a response to a request returning a single conversation
+type ConversationResponse struct {
+	slackResponse
+	Channel Conversation `json:"channel"`
+}
+
+// ConversationListResponse holds a response to a conversation list request
+type ConversationListResponse struct {
+	slackResponse
+	Channels         []Conversation   `json:"channels"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
+}
+
+// ConversationHistoryResponse holds a response to a conversation history or replies request
+type ConversationHistoryResponse struct {
+	slackResponse
+	Messages         []Message        `json:"messages"`
+	HasMore          bool             `json:"has_more"`
+	PinCount         int              `json:"pin_count,omitempty"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
+}
+
+// ConversationMembersResponse holds a response to a conversation members request
+type ConversationMembersResponse struct {
+	slackResponse
+	Members          []string         `json:"members"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
+}
+
+// ConversationOpenResponse holds a response to a conversation open request
+type ConversationOpenResponse struct {
+	slackResponse
+	NoOp        bool         `json:"no_op"`
+	AlreadyOpen bool         `json:"already_open"`
+	Channel     Conversation `json:"channel"`
+}
+
+// conversation calls a conversations method returning a single conversation
+func (s *Slack) conversation(ctx context.Context, method string, params url.Values) (*ConversationResponse, error) {
+	r := &ConversationResponse{}
+	err := s.do(ctx, "conversations."+method, params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// conversationAction calls a conversations method returning just the status
+func (s *Slack) conversationAction(ctx context.Context, method string, params url.Values) (Response, error) {
+	r := &slackResponse{}
+	err := s.do(ctx, "conversations."+method, params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// appendPage adds the cursor and limit of a paginated request
+func appendPage(cursor string, limit int, params url.Values) {
+	appendNotEmpty("cursor", cursor, params)
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+}
+
+// ConversationList lists a page of the conversations of the given types (all public channels
+// if no types are given). Pass the NextCursor of the response as cursor to get the next page.
+func (s *Slack) ConversationList(types []string, excludeArchived bool, cursor string, limit int) (*ConversationListResponse, error) {
+	return s.ConversationListContext(context.Background(), types, excludeArchived, cursor, limit)
+}
+
+// ConversationListContext is ConversationList with a custom context
+func (s *Slack) ConversationListContext(ctx context.Context, types []string, excludeArchived bool, cursor string, limit int) (*ConversationListResponse, error) {
+	params := url.Values{}
+	appendNotEmpty("types", strings.Join(types, ","), params)
+	if excludeArchived {
+		params.Set("exclude_archived", "true")
+	}
+	appendPage(cursor, limit, params)
+	r := &ConversationListResponse{}
+	err := s.do(ctx, "conversations.list", params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationInfo returns information about a conversation
+func (s *Slack) ConversationInfo(channel string, includeNumMembers bool) (*ConversationResponse, error) {
+	return s.ConversationInfoContext(context.Background(), channel, includeNumMembers)
+}
+
+// ConversationInfoContext is ConversationInfo with a custom context
+func (s *Slack) ConversationInfoContext(ctx context.Context, channel string, includeNumMembers bool) (*ConversationResponse, error) {
+	params := url.Values{"channel": {channel}}
+	if includeNumMembers {
+		params.Set("include_num_members", "true")
+	}
+	return s.conversation(ctx, "info", params)
+}
+
+// ConversationHistory returns a page of the messages in the conversation between oldest and latest
+func (s *Slack) ConversationHistory(channel string, latest, oldest Timestamp, inclusive bool, cursor string, limit int) (*ConversationHistoryResponse, error) {
+	return s.ConversationHistoryContext(context.Background(), channel, latest, oldest, inclusive, cursor, limit)
+}
+
+// ConversationHistoryContext is ConversationHistory with a custom context
+func (s *Slack) ConversationHistoryContext(ctx context.Context, channel string, latest, oldest Timestamp, inclusive bool, cursor string, limit int) (*ConversationHistoryResponse, error) {
+	params := url.Values{"channel": {channel}}
+	appendNotEmpty("latest", string(latest), params)
+	appendNotEmpty("oldest", string(oldest), params)
+	if inclusive {
+		params.Set("inclusive", "true")
+	}
+	appendPage(cursor, limit, params)
+	r := &ConversationHistoryResponse{}
+	err := s.do(ctx, "conversations.history", params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationReplies returns a page of the thread of the message with the given timestamp
+func (s *Slack) ConversationReplies(channel string, ts Timestamp, cursor string, limit int) (*ConversationHistoryResponse, error) {
+	return s.ConversationRepliesContext(context.Background(), channel, ts, cursor, limit)
+}
+
+// ConversationRepliesContext is ConversationReplies with a custom context
+func (s *Slack) ConversationRepliesContext(ctx context.Context, channel string, ts Timestamp, cursor string, limit int) (*ConversationHistoryResponse, error) {
+	params := url.Values{"channel": {channel}, "ts": {string(ts)}}
+	appendPage(cursor, limit, params)
+	r := &ConversationHistoryResponse{}
+	err := s.do(ctx, "conversations.replies", params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Thread returns the parent message of the thread followed by all of its replies.
+// The ts can be the timestamp of the parent or of any reply.
+func (s *Slack) Thread(channel string, ts Timestamp) ([]Message, error) {
+	return s.ThreadContext(context.Background(), channel, ts)
+}
+
+// ThreadContext is Thread with a custom context
+func (s *Slack) ThreadContext(ctx context.Context, channel string, ts Timestamp) ([]Message, error) {
+	var messages []Message
+	it := s.RepliesIterContext(ctx, channel, ts, 200)
+	for it.Next() {
+		messages = append(messages, it.Messages...)
+	}
+	return messages, it.Err()
+}
+
+// ConversationMembers returns a page of the member IDs of the conversation
+func (s *Slack) ConversationMembers(channel, cursor string, limit int) (*ConversationMembersResponse, error) {
+	return s.ConversationMembersContext(context.Background(), channel, cursor, limit)
+}
+
+// ConversationMembersContext is ConversationMembers with a custom context
+func (s *Slack) ConversationMembersContext(ctx context.Context, channel, cursor string, limit int) (*ConversationMembersResponse, error) {
+	params := url.Values{"channel": {channel}}
+	appendPage(cursor, limit, params)
+	r := &ConversationMembersResponse{}
+	err := s.do(ctx, "conversations.members", params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationCreate creates a public or private channel
+func (s *Slack) ConversationCreate(name string, isPrivate bool) (*ConversationResponse, error) {
+	return s.ConversationCreateContext(context.Background(), name, isPrivate)
+}
+
+// ConversationCreateContext is ConversationCreate with a custom context
+func (s *Slack) ConversationCreateContext(ctx context.Context, name string, isPrivate bool) (*ConversationResponse, error) {
+	params := url.Values{"name": {name}, "is_private": {strconv.FormatBool(isPrivate)}}
+	return s.conversation(ctx, "create", params)
+}
+
+// ConversationInvite invites users to a channel
+func (s *Slack) ConversationInvite(channel string, users ...string) (*ConversationResponse, error) {
+	return s.ConversationInviteContext(context.Background(), channel, users...)
+}
+
+// ConversationInviteContext is ConversationInvite with a custom context
+func (s *Slack) ConversationInviteContext(ctx context.Context, channel string, users ...string) (*ConversationResponse, error) {
+	params := url.Values{"channel": {channel}, "users": {strings.Join(users, ",")}}
+	return s.conversation(ctx, "invite", params)
+}
+
+// ConversationKick removes a user from a conversation
+func (s *Slack) ConversationKick(channel, user string) (Response, error) {
+	return s.ConversationKickContext(context.Background(), channel, user)
+}
+
+// ConversationKickContext is ConversationKick with a custom context
+func (s *Slack) ConversationKickContext(ctx context.Context, channel, user string) (Response, error) {
+	return s.conversationAction(ctx, "kick", url.Values{"channel": {channel}, "user": {user}})
+}
+
+// ConversationJoin joins an existing conversation
+func (s *Slack) ConversationJoin(channel string) (*ConversationResponse, error) {
+	return s.ConversationJoinContext(context.Background(), channel)
+}
+
+// ConversationJoinContext is ConversationJoin with a custom context
+func (s *Slack) ConversationJoinContext(ctx context.Context, channel string) (*ConversationResponse, error) {
+	return s.conversation(ctx, "join", url.Values{"channel": {channel}})
+}
+
+// ConversationLeave leaves a conversation
+func (s *Slack) ConversationLeave(channel string) (Response, error) {
+	return s.ConversationLeaveContext(context.Background(), channel)
+}
+
+// ConversationLeaveContext is ConversationLeave with a custom context
+func (s *Slack) ConversationLeaveContext(ctx context.Context, channel string) (Response, error) {
+	return s.conversationAction(ctx, "leave", url.Values{"channel": {channel}})
+}
+
+// ConversationArchive archives a conversation
+func (s *Slack) ConversationArchive(channel string) (Response, error) {
+	return s.ConversationArchiveContext(context.Background(), channel)
+}
+
+// ConversationArchiveContext is ConversationArchive with a custom context
+func (s *Slack) ConversationArchiveContext(ctx context.Context, channel string) (Response, error) {
+	return s.conversationAction(ctx, "archive", url.Values{"channel": {channel}})
+}
+
+// ConversationUnarchive reverses conversation archival
+func (s *Slack) ConversationUnarchive(channel string) (Response, error) {
+	return s.ConversationUnarchiveContext(context.Background(), channel)
+}
+
+// ConversationUnarchiveContext is ConversationUnarchive with a custom context
+func (s *Slack) ConversationUnarchiveContext(ctx context.Context, channel string) (Response, error) {
+	return s.conversationAction(ctx, "unarchive", url.Values{"channel": {channel}})
+}
+
+// ConversationRename renames a conversation
+func (s *Slack) ConversationRename(channel, name string) (*ConversationResponse, error) {
+	return s.ConversationRenameContext(context.Background(), channel, name)
+}
+
+// ConversationRenameContext is ConversationRename with a custom context
+func (s *Slack) ConversationRenameContext(ctx context.Context, channel, name string) (*ConversationResponse, error) {
+	return s.conversation(ctx, "rename", url.Values{"channel": {channel}, "name": {name}})
+}
+
+// ConversationSetTopic sets the topic of a conversation
+func (s *Slack) ConversationSetTopic(channel, topic string) (*ConversationResponse, error) {
+	return s.ConversationSetTopicContext(context.Background(), channel, topic)
+}
+
+// ConversationSetTopicContext is ConversationSetTopic with a custom context
+func (s *Slack) ConversationSetTopicContext(ctx context.Context, channel, topic string) (*ConversationResponse, error) {
+	return s.conversation(ctx, "setTopic", url.Values{"channel": {channel}, "topic": {topic}})
+}
+
+// ConversationSetPurpose sets the purpose of a conversation
+func (s *Slack) ConversationSetPurpose(channel, purpose string) (*ConversationResponse, error) {
+	return s.ConversationSetPurposeContext(context.Background(), channel, purpose)
+}
+
+// ConversationSetPurposeContext is ConversationSetPurpose with a custom context
+func (s *Slack) ConversationSetPurposeContext(ctx context.Context, channel, purpose string) (*ConversationResponse, error) {
+	return s.conversation(ctx, "setPurpose", url.Values{"channel": {channel}, "purpose": {purpose}})
+}
+
+// ConversationOpen opens or resumes an IM or MPIM. Either give the channel to resume or the users
+// to open the conversation with.
+func (s *Slack) ConversationOpen(channel string, users []string) (*ConversationOpenResponse, error) {
+	return s.ConversationOpenContext(context.Background(), channel, users)
+}
+
+// ConversationOpenContext is ConversationOpen with a custom context
+func (s *Slack) ConversationOpenContext(ctx context.Context, channel string, users []string) (*ConversationOpenResponse, error) {
+	params := url.Values{"return_im": {"true"}}
+	appendNotEmpty("channel", channel, params)
+	appendNotEmpty("users", strings.Join(users, ","), params)
+	r := &ConversationOpenResponse{}
+	err := s.do(ctx, "conversations.open", params, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationClose closes an IM or MPIM
+func (s *Slack) ConversationClose(channel string) (*CloseResponse, error) {
+	return s.ConversationCloseContext(context.Background(), channel)
+}
+
+// ConversationCloseContext is ConversationClose with a custom context
+func (s *Slack) ConversationCloseContext(ctx context.Context, channel string) (*CloseResponse, error) {
+	r := &CloseResponse{}
+	err := s.do(ctx, "conversations.close", url.Values{"channel": {channel}}, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ConversationMark sets the read cursor of a conversation
+func (s *Slack) ConversationMark(channel string, ts Timestamp) error {
+	return s.ConversationMarkContext(context.Background(), channel, ts)
+}
+
+// ConversationMarkContext is ConversationMark with a custom context
+func (s *Slack) ConversationMarkContext(ctx context.Context, channel string, ts Timestamp) error {
+	_, err := s.conversationAction(ctx, "mark", url.Values{"channel": {channel}, "ts": {string(ts)}})
+	return err
+}
@@ -0,0 +1,269 @@
+package slack
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	gerr "github.com/go-errors/errors"
+)
+
+// HandlerFunc handles a single event
+type HandlerFunc func(e Event)
+
+// Middleware wraps the handling of every event, for example to log or filter events
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Dispatcher routes the typed RTM events to the handlers registered for their type
+// and, for messages, their subtype. A panic in a handler is recovered and logged so
+// it does not affect other handlers.
+//
+// Example:
+//
+//	d := slack.NewDispatcher()
+//	d.OnMessage(func(m *slack.MessageEvent) { ... })
+//	d.On("channel_rename", func(e slack.Event) { ... })
+//	go d.Run(ctx, events)
+type Dispatcher struct {
+	mutex      sync.RWMutex
+	handlers   map[string][]HandlerFunc // Handlers by event type or message subtype key
+	middleware []Middleware             // Middleware applied to every event
+	workers    int                      // Number of concurrent workers - 0 handles events in order
+	errorlog   *log.Logger              // Optional logger to write handler panics to
+}
+
+// NewDispatcher creates a new dispatcher that handles the events one after the other
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{handlers: make(map[string][]HandlerFunc)}
+}
+
+// SetWorkers sets the number of events handled concurrently by Run. Zero or less
+// handles the events one after the other which keeps their order.
+func (d *Dispatcher) SetWorkers(workers int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.workers = workers
+}
+
+// SetErrorLog sets the logger for recovered handler panics
+func (d *Dispatcher) SetErrorLog(logger *log.Logger) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.errorlog = logger
+}
+
+// Use adds middleware. The first middleware added is the outermost one.
+func (d *Dispatcher) Use(middleware ...Middleware) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.middleware = append(d.middleware, middleware...)
+}
+
+// On registers a handler for the given event type
+func (d *Dispatcher) On(eventType string, h HandlerFunc) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.handlers[eventType] = append(d.handlers[eventType], h)
+}
+
+// subtypeKey is the handlers key for a message subtype
+func subtypeKey(subtype string) string {
+	return "message/" + subtype
+}
+
+// messageEvent is implemented by MessageEvent and the typed message subtype events
+type messageEvent interface {
+	messageEvent() *MessageEvent
+}
+
+// OnMessage registers a handler for all messages regardless of their subtype
+func (d *Dispatcher) OnMessage(h func(*MessageEvent)) {
+	d.On("message", func(e Event) {
+		if m, ok := e.(messageEvent); ok {
+			h(m.messageEvent())
+		}
+	})
+}
+
+// OnMessageSubtype registers a handler for messages with the given subtype.
+// Use an empty subtype for plain user messages.
+func (d *Dispatcher) OnMessageSubtype(subtype string, h func(*MessageEvent)) {
+	d.On(subtypeKey(subtype), func(e Event) {
+		if m, ok := e.(messageEvent); ok {
+			h(m.messageEvent())
+		}
+	})
+}
+
+// OnThreadReply registers a handler for replies in threads, including broadcast replies
+func (d *Dispatcher) OnThreadReply(h func(*MessageEvent)) {
+	d.OnMessage(func(m *MessageEvent) {
+		if m.IsThreadReply() {
+			h(m)
+		}
+	})
+}
+
+// OnMessageChanged registers a handler for edited messages
+func (d *Dispatcher) OnMessageChanged(h func(*MessageChangedEvent)) {
+	d.On(subtypeKey("message_changed"), func(e Event) {
+		if m, ok := e.(*MessageChangedEvent); ok {
+			h(m)
+		}
+	})
+}
+
+// OnMessageDeleted registers a handler for deleted messages
+func (d *Dispatcher) OnMessageDeleted(h func(*MessageDeletedEvent)) {
+	d.On(subtypeKey("message_deleted"), func(e Event) {
+		if m, ok := e.(*MessageDeletedEvent); ok {
+			h(m)
+		}
+	})
+}
+
+// OnReactionAdded registers a handler for reaction_added events
+func (d *Dispatcher) OnReactionAdded(h func(*ReactionAddedEvent)) {
+	d.On("reaction_added", func(e Event) {
+		if r, ok := e.(*ReactionAddedEvent); ok {
+			h(r)
+		}
+	})
+}
+
+// OnReactionRemoved registers a handler for reaction_removed events
+func (d *Dispatcher) OnReactionRemoved(h func(*ReactionRemovedEvent)) {
+	d.On("reaction_removed", func(e Event) {
+		if r, ok := e.(*ReactionRemovedEvent); ok {
+			h(r)
+		}
+	})
+}
+
+// OnChannelCreated registers a handler for channel_created events
+func (d *Dispatcher) OnChannelCreated(h func(*ChannelCreatedEvent)) {
+	d.On("channel_created", func(e Event) {
+		if c, ok := e.(*ChannelCreatedEvent); ok {
+			h(c)
+		}
+	})
+}
+
+// OnUserChange registers a handler for user_change events
+func (d *Dispatcher) OnUserChange(h func(*UserChangeEvent)) {
+	d.On("user_change", func(e Event) {
+		if u, ok := e.(*UserChangeEvent); ok {
+			h(u)
+		}
+	})
+}
+
+// OnPresenceChange registers a handler for presence_change events
+func (d *Dispatcher) OnPresenceChange(h func(*PresenceChangeEvent)) {
+	d.On("presence_change", func(e Event) {
+		if p, ok := e.(*PresenceChangeEvent); ok {
+			h(p)
+		}
+	})
+}
+
+// OnError registers a handler for error events - both from Slack and from reading the RTM
+func (d *Dispatcher) OnError(h func(*ErrorEvent)) {
+	d.On("error", func(e Event) {
+		if ee, ok := e.(*ErrorEvent); ok {
+			h(ee)
+		}
+	})
+}
+
+// Dispatch handles a single event synchronously through the middleware and the matching handlers
+func (d *Dispatcher) Dispatch(e Event) {
+	d.mutex.RLock()
+	h := HandlerFunc(d.route)
+	for i := len(d.middleware) - 1; i >= 0; i-- {
+		h = d.middleware[i](h)
+	}
+	d.mutex.RUnlock()
+	d.safeCall(h, e)
+}
+
+// route calls the handlers registered for the event
+func (d *Dispatcher) route(e Event) {
+	d.mutex.RLock()
+	handlers := d.handlers[e.EventType()]
+	if m, ok := e.(messageEvent); ok {
+		handlers = append(handlers[:len(handlers):len(handlers)], d.handlers[subtypeKey(m.messageEvent().Subtype)]...)
+	}
+	d.mutex.RUnlock()
+	for _, h := range handlers {
+		d.safeCall(h, e)
+	}
+}
+
+// safeCall calls the handler and recovers from any panic
+func (d *Dispatcher) safeCall(h HandlerFunc, e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			d.mutex.RLock()
+			errorlog := d.errorlog
+			d.mutex.RUnlock()
+			if errorlog != nil {
+				errorlog.Printf("Recovered from panic handling %s event, %v\n", e.EventType(), r)
+				errorlog.Println(gerr.Wrap(r, 2).ErrorStack())
+			}
+		}
+	}()
+	h(e)
+}
+
+// Run dispatches the events until the channel is closed or the context is done.
+// With workers set, up to that many events are handled concurrently and Run waits
+// for the running handlers before returning.
+func (d *Dispatcher) Run(ctx context.Context, events <-chan Event) error {
+	d.mutex.RLock()
+	workers := d.workers
+	d.mutex.RUnlock()
+	if workers <= 0 {
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case e, ok := <-events:
+				if !ok {
+					return nil
+				}
+				d.Dispatch(e)
+			}
+		}
+	}
+	jobs := make(chan Event)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range jobs {
+				d.Dispatch(e)
+			}
+		}()
+	}
+	defer func() {
+		close(jobs)
+		wg.Wait()
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			select {
+			case jobs <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
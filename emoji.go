@@ -1,6 +1,9 @@
 package slack
 
-import "net/url"
+import (
+	"context"
+	"net/url"
+)
 
 // EmojiListResponse is returned for the emoji list request
 type EmojiListResponse struct {
@@ -10,9 +13,14 @@ type EmojiListResponse struct {
 
 // EmojiList returns the list of emoji
 func (s *Slack) EmojiList() (*EmojiListResponse, error) {
+	return s.EmojiListContext(context.Background())
+}
+
+// EmojiListContext is EmojiList with a custom context
+func (s *Slack) EmojiListContext(ctx context.Context) (*EmojiListResponse, error) {
 	params := url.Values{}
 	r := &EmojiListResponse{}
-	err := s.do("emoji.list", params, r)
+	err := s.do(ctx, "emoji.list", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,413 @@
+package slack
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Event is implemented by all the typed RTM and Events API events - see https://api.slack.com/events
+type Event interface {
+	EventType() string
+}
+
+// BaseEvent holds the fields common to most events
+type BaseEvent struct {
+	Type           string    `json:"type"`
+	EventTimestamp Timestamp `json:"event_ts,omitempty"`
+}
+
+// EventType of the event is returned
+func (e *BaseEvent) EventType() string {
+	return e.Type
+}
+
+// UnknownEvent is returned for event types the library does not know yet
+type UnknownEvent struct {
+	BaseEvent
+	Raw json.RawMessage `json:"-"`
+}
+
+// ErrorEvent is sent by Slack when something goes wrong and by the library when reading fails
+type ErrorEvent struct {
+	BaseEvent
+	Error struct {
+		Code       int    `json:"code"`
+		Msg        string `json:"msg"`
+		Unmarshall bool   `json:"unmarshall"` // Is this an unmarshall error and not request error
+	} `json:"error"`
+}
+
+// LifecycleEvent is sent by the library for the RTM connection lifecycle - see SetRTMReconnect
+type LifecycleEvent struct {
+	BaseEvent
+	Error string `json:"error,omitempty"`
+}
+
+// HelloEvent is sent when the connection is established
+type HelloEvent struct {
+	BaseEvent
+}
+
+// PongEvent is the reply to a ping
+type PongEvent struct {
+	BaseEvent
+	ReplyTo int `json:"reply_to"`
+}
+
+// MessageEvent is a message sent to a channel
+type MessageEvent struct {
+	Message
+}
+
+// EventType of the event is returned
+func (e *MessageEvent) EventType() string {
+	return e.Type
+}
+
+// messageEvent returns the message so the subtype events can be handled as plain messages
+func (e *MessageEvent) messageEvent() *MessageEvent {
+	return e
+}
+
+// MessageChangedEvent is a message with the message_changed subtype sent when a message is edited
+type MessageChangedEvent struct {
+	MessageEvent
+	Current  *Message `json:"message"`          // The message after the edit
+	Previous *Message `json:"previous_message"` // The message before the edit
+}
+
+// MessageDeletedEvent is a message with the message_deleted subtype sent when a message is deleted.
+// The timestamp of the deleted message is in DeletedTS.
+type MessageDeletedEvent struct {
+	MessageEvent
+	Previous *Message `json:"previous_message"` // The deleted message
+}
+
+// AppMentionEvent is sent by the Events API when the app is mentioned
+type AppMentionEvent struct {
+	BaseEvent
+	User            string    `json:"user"`
+	Text            string    `json:"text"`
+	Timestamp       Timestamp `json:"ts"`
+	Channel         string    `json:"channel"`
+	ThreadTimestamp Timestamp `json:"thread_ts,omitempty"`
+}
+
+// UserTypingEvent is sent when a user is typing in a channel
+type UserTypingEvent struct {
+	BaseEvent
+	Channel string `json:"channel"`
+	User    string `json:"user"`
+}
+
+// ChannelCreatedEvent is sent when a channel is created
+type ChannelCreatedEvent struct {
+	BaseEvent
+	Channel Channel `json:"channel"`
+}
+
+// ChannelJoinedEvent is sent when you join a channel
+type ChannelJoinedEvent struct {
+	BaseEvent
+	Channel Channel `json:"channel"`
+}
+
+// ChannelLeftEvent is sent when you leave a channel
+type ChannelLeftEvent struct {
+	BaseEvent
+	Channel string `json:"channel"`
+}
+
+// RenamedChannel holds the new details of a renamed channel or group
+type RenamedChannel struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Created int64  `json:"created"`
+}
+
+// ChannelRenameEvent is sent when a channel is renamed
+type ChannelRenameEvent struct {
+	BaseEvent
+	Channel RenamedChannel `json:"channel"`
+}
+
+// ChannelArchiveEvent is sent when a channel is archived
+type ChannelArchiveEvent struct {
+	BaseEvent
+	Channel string `json:"channel"`
+	User    string `json:"user"`
+}
+
+// ChannelUnarchiveEvent is sent when a channel is unarchived
+type ChannelUnarchiveEvent struct {
+	BaseEvent
+	Channel string `json:"channel"`
+	User    string `json:"user"`
+}
+
+// ChannelDeletedEvent is sent when a channel is deleted
+type ChannelDeletedEvent struct {
+	BaseEvent
+	Channel string `json:"channel"`
+}
+
+// MemberJoinedChannelEvent is sent when a user joins a channel
+type MemberJoinedChannelEvent struct {
+	BaseEvent
+	User        string `json:"user"`
+	Channel     string `json:"channel"`
+	ChannelType string `json:"channel_type"`
+	Team        string `json:"team"`
+	Inviter     string `json:"inviter,omitempty"`
+}
+
+// MemberLeftChannelEvent is sent when a user leaves a channel
+type MemberLeftChannelEvent struct {
+	BaseEvent
+	User        string `json:"user"`
+	Channel     string `json:"channel"`
+	ChannelType string `json:"channel_type"`
+	Team        string `json:"team"`
+}
+
+// GroupJoinedEvent is sent when you join a group
+type GroupJoinedEvent struct {
+	BaseEvent
+	Channel Group `json:"channel"`
+}
+
+// GroupLeftEvent is sent when you leave a group
+type GroupLeftEvent struct {
+	BaseEvent
+	Channel string `json:"channel"`
+}
+
+// GroupRenameEvent is sent when a group is renamed
+type GroupRenameEvent struct {
+	BaseEvent
+	Channel RenamedChannel `json:"channel"`
+}
+
+// GroupArchiveEvent is sent when a group is archived
+type GroupArchiveEvent struct {
+	BaseEvent
+	Channel string `json:"channel"`
+}
+
+// GroupUnarchiveEvent is sent when a group is unarchived
+type GroupUnarchiveEvent struct {
+	BaseEvent
+	Channel string `json:"channel"`
+}
+
+// GroupOpenEvent is sent when you open a group
+type GroupOpenEvent struct {
+	BaseEvent
+	User    string `json:"user"`
+	Channel string `json:"channel"`
+}
+
+// GroupCloseEvent is sent when you close a group
+type GroupCloseEvent struct {
+	BaseEvent
+	User    string `json:"user"`
+	Channel string `json:"channel"`
+}
+
+// IMCreatedEvent is sent when a DM is created
+type IMCreatedEvent struct {
+	BaseEvent
+	User    string `json:"user"`
+	Channel IM     `json:"channel"`
+}
+
+// IMOpenEvent is sent when you open a DM
+type IMOpenEvent struct {
+	BaseEvent
+	User    string `json:"user"`
+	Channel string `json:"channel"`
+}
+
+// IMCloseEvent is sent when you close a DM
+type IMCloseEvent struct {
+	BaseEvent
+	User    string `json:"user"`
+	Channel string `json:"channel"`
+}
+
+// UserChangeEvent is sent when a user's data changes
+type UserChangeEvent struct {
+	BaseEvent
+	User User `json:"user"`
+}
+
+// TeamJoinEvent is sent when a new user joins the team
+type TeamJoinEvent struct {
+	BaseEvent
+	User User `json:"user"`
+}
+
+// TeamRenameEvent is sent when the team is renamed
+type TeamRenameEvent struct {
+	BaseEvent
+	Name string `json:"name"`
+}
+
+// PresenceChangeEvent is sent when the presence of a user or a batch of users changes
+type PresenceChangeEvent struct {
+	BaseEvent
+	User     string   `json:"user,omitempty"`
+	Users    []string `json:"users,omitempty"`
+	Presence string   `json:"presence"`
+}
+
+// ReactionItem is the item a reaction was added to or removed from
+type ReactionItem struct {
+	Type        string    `json:"type"`
+	Channel     string    `json:"channel,omitempty"`
+	Timestamp   Timestamp `json:"ts,omitempty"`
+	File        string    `json:"file,omitempty"`
+	FileComment string    `json:"file_comment,omitempty"`
+}
+
+// ReactionAddedEvent is sent when a reaction is added to an item
+type ReactionAddedEvent struct {
+	BaseEvent
+	User     string       `json:"user"`
+	Reaction string       `json:"reaction"`
+	ItemUser string       `json:"item_user,omitempty"`
+	Item     ReactionItem `json:"item"`
+}
+
+// ReactionRemovedEvent is sent when a reaction is removed from an item
+type ReactionRemovedEvent struct {
+	BaseEvent
+	User     string       `json:"user"`
+	Reaction string       `json:"reaction"`
+	ItemUser string       `json:"item_user,omitempty"`
+	Item     ReactionItem `json:"item"`
+}
+
+// FileSharedEvent is sent when a file is shared
+type FileSharedEvent struct {
+	BaseEvent
+	FileID string `json:"file_id"`
+	File   File   `json:"file"`
+}
+
+// FileCreatedEvent is sent when a file is created
+type FileCreatedEvent struct {
+	BaseEvent
+	FileID string `json:"file_id"`
+	File   File   `json:"file"`
+}
+
+// FileChangeEvent is sent when a file is changed
+type FileChangeEvent struct {
+	BaseEvent
+	FileID string `json:"file_id"`
+	File   File   `json:"file"`
+}
+
+// FileDeletedEvent is sent when a file is deleted
+type FileDeletedEvent struct {
+	BaseEvent
+	FileID string `json:"file_id"`
+}
+
+// EmojiChangedEvent is sent when a custom emoji is added, removed or changed
+type EmojiChangedEvent struct {
+	BaseEvent
+	Subtype string   `json:"subtype"`
+	Name    string   `json:"name,omitempty"`
+	Names   []string `json:"names,omitempty"`
+	Value   string   `json:"value,omitempty"`
+}
+
+// BotAddedEvent is sent when a bot is added
+type BotAddedEvent struct {
+	BaseEvent
+	Bot Bot `json:"bot"`
+}
+
+// BotChangedEvent is sent when a bot is changed
+type BotChangedEvent struct {
+	BaseEvent
+	Bot Bot `json:"bot"`
+}
+
+// eventTypes maps the event type to a constructor of the typed event
+var eventTypes = map[string]func() Event{
+	"error":                 func() Event { return &ErrorEvent{} },
+	"hello":                 func() Event { return &HelloEvent{} },
+	"pong":                  func() Event { return &PongEvent{} },
+	"message":               func() Event { return &MessageEvent{} },
+	"app_mention":           func() Event { return &AppMentionEvent{} },
+	"user_typing":           func() Event { return &UserTypingEvent{} },
+	"channel_created":       func() Event { return &ChannelCreatedEvent{} },
+	"channel_joined":        func() Event { return &ChannelJoinedEvent{} },
+	"channel_left":          func() Event { return &ChannelLeftEvent{} },
+	"channel_rename":        func() Event { return &ChannelRenameEvent{} },
+	"channel_archive":       func() Event { return &ChannelArchiveEvent{} },
+	"channel_unarchive":     func() Event { return &ChannelUnarchiveEvent{} },
+	"channel_deleted":       func() Event { return &ChannelDeletedEvent{} },
+	"member_joined_channel": func() Event { return &MemberJoinedChannelEvent{} },
+	"member_left_channel":   func() Event { return &MemberLeftChannelEvent{} },
+	"group_joined":          func() Event { return &GroupJoinedEvent{} },
+	"group_left":            func() Event { return &GroupLeftEvent{} },
+	"group_rename":          func() Event { return &GroupRenameEvent{} },
+	"group_archive":         func() Event { return &GroupArchiveEvent{} },
+	"group_unarchive":       func() Event { return &GroupUnarchiveEvent{} },
+	"group_open":            func() Event { return &GroupOpenEvent{} },
+	"group_close":           func() Event { return &GroupCloseEvent{} },
+	"im_created":            func() Event { return &IMCreatedEvent{} },
+	"im_open":               func() Event { return &IMOpenEvent{} },
+	"im_close":              func() Event { return &IMCloseEvent{} },
+	"user_change":           func() Event { return &UserChangeEvent{} },
+	"team_join":             func() Event { return &TeamJoinEvent{} },
+	"team_rename":           func() Event { return &TeamRenameEvent{} },
+	"presence_change":       func() Event { return &PresenceChangeEvent{} },
+	"reaction_added":        func() Event { return &ReactionAddedEvent{} },
+	"reaction_removed":      func() Event { return &ReactionRemovedEvent{} },
+	"file_shared":           func() Event { return &FileSharedEvent{} },
+	"file_created":          func() Event { return &FileCreatedEvent{} },
+	"file_change":           func() Event { return &FileChangeEvent{} },
+	"file_deleted":          func() Event { return &FileDeletedEvent{} },
+	"emoji_changed":         func() Event { return &EmojiChangedEvent{} },
+	"bot_added":             func() Event { return &BotAddedEvent{} },
+	"bot_changed":           func() Event { return &BotChangedEvent{} },
+}
+
+// messageSubtypes maps the message subtype to a constructor of the typed message event
+var messageSubtypes = map[string]func() Event{
+	"message_changed": func() Event { return &MessageChangedEvent{} },
+	"message_deleted": func() Event { return &MessageDeletedEvent{} },
+}
+
+// DecodeEvent decodes a raw event into the matching typed event based on its type.
+// Unknown event types are returned as *UnknownEvent.
+func DecodeEvent(p []byte) (Event, error) {
+	typeMsg := &baseTypeMessage{}
+	if err := json.Unmarshal(p, typeMsg); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	create, ok := eventTypes[typeMsg.Type]
+	if c, sok := messageSubtypes[typeMsg.Subtype]; sok && typeMsg.Type == "message" {
+		create = c
+	}
+	if !ok {
+		e := &UnknownEvent{Raw: json.RawMessage(p)}
+		if err := json.Unmarshal(p, &e.BaseEvent); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	e := create()
+	if err := json.Unmarshal(p, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,184 @@
+package slack
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Events API request types
+const (
+	EventsURLVerification = "url_verification"
+	EventsCallback        = "event_callback"
+	EventsAppRateLimited  = "app_rate_limited"
+)
+
+// DefaultDedupWindow is how long event IDs are remembered to drop retried deliveries
+const DefaultDedupWindow = time.Hour
+
+// EventsAPIRequest is the outer payload of the Events API requests - see https://api.slack.com/apis/connections/events-api
+type EventsAPIRequest struct {
+	Token       string          `json:"token"`
+	Type        string          `json:"type"`
+	Challenge   string          `json:"challenge,omitempty"`
+	TeamID      string          `json:"team_id,omitempty"`
+	APIAppID    string          `json:"api_app_id,omitempty"`
+	EventID     string          `json:"event_id,omitempty"`
+	EventTime   int64           `json:"event_time,omitempty"`
+	AuthedUsers []string        `json:"authed_users,omitempty"`
+	RawEvent    json.RawMessage `json:"event,omitempty"`
+}
+
+// EventCallback is an event delivered by the Events API
+type EventCallback struct {
+	EventsAPIRequest
+	Event       Event  // The decoded event - see DecodeEvent
+	RetryNum    int    // The retry number from X-Slack-Retry-Num - 0 for the first delivery
+	RetryReason string // The reason for the retry from X-Slack-Retry-Reason
+}
+
+// ParseEventCallback decodes an event_callback payload including the inner event
+func ParseEventCallback(body []byte) (*EventCallback, error) {
+	cb := &EventCallback{}
+	if err := json.Unmarshal(body, &cb.EventsAPIRequest); err != nil {
+		return nil, err
+	}
+	if len(cb.RawEvent) == 0 {
+		return nil, newError("bad_event", "Missing event in the %s request", cb.Type)
+	}
+	ev, err := DecodeEvent(cb.RawEvent)
+	if err != nil {
+		return nil, err
+	}
+	cb.Event = ev
+	return cb, nil
+}
+
+// EventsHandler is an http.Handler receiving the Events API requests. It verifies the
+// request signature, answers url_verification challenges, decodes the event callbacks
+// into the same event types as the RTM and drops retried deliveries of events it
+// already handled.
+//
+// OnEvent is called synchronously and Slack expects an answer within 3 seconds so long
+// running work should be moved to a goroutine. An event counts as handled once OnEvent
+// returns - if it panics the event ID is forgotten so the retry from Slack is delivered.
+// Work moved to a goroutine is owned by the caller which must handle its failures.
+type EventsHandler struct {
+	Verifier
+	OnEvent     func(*EventCallback) // Called for every new event callback. Required.
+	DedupWindow time.Duration        // How long to remember event IDs - DefaultDedupWindow if 0
+	ErrorLog    *log.Logger          // Optional logger to write rejected requests to
+	mutex       sync.Mutex
+	seen        map[string]time.Time // Event ID to the time it was received
+	lastSweep   time.Time            // When expired event IDs were last removed from seen
+}
+
+// NewEventsHandler creates a handler for the given signing secret. With an empty secret
+// every request is rejected with ErrNoSigningSecret.
+func NewEventsHandler(signingSecret string, onEvent func(*EventCallback)) *EventsHandler {
+	return &EventsHandler{Verifier: Verifier{SigningSecret: signingSecret}, OnEvent: onEvent}
+}
+
+// errorf logs to the error log if defined
+func (h *EventsHandler) errorf(format string, args ...interface{}) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, args...)
+	}
+}
+
+// firstSeen records the event ID and returns false if it was already seen in the window.
+// The ID is recorded before OnEvent runs so a retry arriving while it runs is dropped.
+func (h *EventsHandler) firstSeen(id string) bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	window := h.DedupWindow
+	if window == 0 {
+		window = DefaultDedupWindow
+	}
+	if h.seen == nil {
+		h.seen = make(map[string]time.Time)
+	}
+	now := time.Now()
+	// Sweep once per window so every delivery does not pay for scanning all the IDs
+	if now.Sub(h.lastSweep) > window {
+		for k, t := range h.seen {
+			if now.Sub(t) > window {
+				delete(h.seen, k)
+			}
+		}
+		h.lastSweep = now
+	}
+	if t, ok := h.seen[id]; ok && now.Sub(t) <= window {
+		return false
+	}
+	h.seen[id] = now
+	return true
+}
+
+// forget removes the event ID so a retry of the event is handled again
+func (h *EventsHandler) forget(id string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.seen, id)
+}
+
+// handle calls OnEvent and forgets the event if OnEvent does not return
+func (h *EventsHandler) handle(cb *EventCallback) {
+	handled := false
+	defer func() {
+		if !handled && cb.EventID != "" {
+			h.forget(cb.EventID)
+		}
+	}()
+	h.OnEvent(cb)
+	handled = true
+}
+
+// ServeHTTP handles the Events API request
+func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := h.Verify(r)
+	if err != nil {
+		h.errorf("Rejected Events API request - %v\n", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	req := &EventsAPIRequest{}
+	if err = json.Unmarshal(body, req); err != nil {
+		h.errorf("Error unmarshaling Events API request - %s\n", string(body))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	switch req.Type {
+	case EventsURLVerification:
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(req.Challenge))
+	case EventsCallback:
+		cb, err := ParseEventCallback(body)
+		if err != nil {
+			h.errorf("Error decoding event callback - %s\n", string(body))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		cb.RetryNum, _ = strconv.Atoi(r.Header.Get("X-Slack-Retry-Num"))
+		cb.RetryReason = r.Header.Get("X-Slack-Retry-Reason")
+		if cb.EventID != "" && !h.firstSeen(cb.EventID) {
+			// A retry of an event we already handled
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if h.OnEvent != nil {
+			h.handle(cb)
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		// app_rate_limited and future types just need an ack
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,43 @@
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEventsHandlerEmptySecret(t *testing.T) {
+	called := false
+	h := NewEventsHandler("", func(*EventCallback) { called = true })
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest("", `{"type":"event_callback","event_id":"Ev1"}`, time.Now()))
+	if w.Code != http.StatusUnauthorized || called {
+		t.Errorf("empty secret status = %d, called = %v, want %d and no call", w.Code, called, http.StatusUnauthorized)
+	}
+}
+
+func TestEventsHandlerRetryAfterPanic(t *testing.T) {
+	calls := 0
+	h := NewEventsHandler(testSecret, func(*EventCallback) {
+		calls++
+		if calls == 1 {
+			panic("handler failed")
+		}
+	})
+	body := `{"type":"event_callback","event_id":"Ev1","event":{"type":"message","text":"hi"}}`
+	func() {
+		defer func() { recover() }()
+		h.ServeHTTP(httptest.NewRecorder(), signedRequest(testSecret, body, time.Now()))
+	}()
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, signedRequest(testSecret, body, time.Now()))
+		if w.Code != http.StatusOK {
+			t.Fatalf("retry %d status = %d, want %d", i+1, w.Code, http.StatusOK)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("OnEvent called %d times, want 2 - the retry after the panic and no duplicate", calls)
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/boltdb/bolt"
 	"github.com/demisto/slack"
+	"github.com/demisto/slack/mrkdwn"
 )
 
 const (
@@ -56,7 +57,10 @@ type message struct {
 
 // Key for the message that is also sortable
 func (m *message) Key() string {
-	return m.TS.Format(time.RFC3339) + "|" + m.User
+	// Keep the microseconds of the Slack timestamp so messages in the same second get distinct keys.
+	// Databases written with the older RFC3339 keys in local time would get every message again
+	// when the history is re-imported so start those with a new database.
+	return m.TS.UTC().Format("2006-01-02T15:04:05.000000Z07:00") + "|" + m.User
 }
 
 // handler of the DB saving
@@ -181,7 +185,7 @@ func translateUser(id string) string {
 }
 
 func translateChannel(id string) string {
-	if id[0] != 'C' {
+	if slack.ChannelID(id).Kind() != slack.PublicChannelKind {
 		return id
 	}
 	for i := range info.Channels {
@@ -199,11 +203,10 @@ func translateChannel(id string) string {
 }
 
 func formatTime(msg *slack.Message) time.Time {
-	t, err := slack.TimestampToTime(msg.Timestamp)
-	if err != nil {
-		t = time.Now()
+	if !msg.Timestamp.Valid() {
+		return time.Now()
 	}
-	return t
+	return msg.Timestamp.Time()
 }
 
 func interestedIn(ch string) bool {
@@ -219,34 +222,19 @@ func interestedIn(ch string) bool {
 	return false
 }
 
+// names resolves the mentions in messages using the team info
+type names struct{}
+
+func (names) UserName(id string) string {
+	return translateUser(id)
+}
+
+func (names) ChannelName(id string) string {
+	return translateChannel(id)
+}
+
 func translateMessage(msg *slack.Message, ch string) *message {
-	text := msg.Text
-	for {
-		index := strings.Index(text, "<@")
-		if index < 0 {
-			break
-		}
-		end := strings.Index(text[index+2:], ">")
-		if end < 0 {
-			break
-		}
-		parts := strings.Split(text[index+2:index+2+end], "|")
-		if len(parts) == 2 {
-			if parts[0][0] == 'U' {
-				text = "@" + parts[1] + text[end+index+3:]
-			} else {
-				text = "#" + parts[1] + text[end+index+3:]
-			}
-		} else if len(parts) == 1 {
-			if len(parts[0]) > 0 {
-				if parts[0][0] == 'U' {
-					text = "@" + translateUser(parts[0]) + text[end+index+3:]
-				} else if parts[0][0] == 'C' {
-					text = "#" + translateChannel(parts[0]) + text[end+index+3:]
-				}
-			}
-		}
-	}
+	text := mrkdwn.ToPlainText(msg.Text, names{})
 	return &message{User: translateUser(msg.User), Channel: ch, Text: text, TS: formatTime(msg)}
 }
 
@@ -281,7 +269,7 @@ func receiveMessages(s *slack.Slack, in chan *slack.Message, stop chan bool, h *
 			} else {
 				switch msg.Type {
 				case "message":
-					if msg.Channel[0] != 'C' {
+					if msg.ChannelKind() != slack.PublicChannelKind {
 						continue
 					}
 					ch := translateChannel(msg.Channel)
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/demisto/slack"
+	"github.com/demisto/slack/mrkdwn"
 )
 
 var (
@@ -62,11 +63,10 @@ func translateChannel(id string) string {
 }
 
 func formatTime(msg *slack.Message) int64 {
-	t, err := slack.TimestampToTime(msg.Timestamp)
-	if err != nil {
-		t = time.Now()
+	if !msg.Timestamp.Valid() {
+		return time.Now().Unix()
 	}
-	return t.Unix()
+	return msg.Timestamp.Time().Unix()
 }
 
 func interestedIn(ch string) bool {
@@ -82,34 +82,19 @@ func interestedIn(ch string) bool {
 	return false
 }
 
+// names resolves the mentions in messages using the team info
+type names struct{}
+
+func (names) UserName(id string) string {
+	return translateUser(id)
+}
+
+func (names) ChannelName(id string) string {
+	return translateChannel(id)
+}
+
 func translateMessage(msg *slack.Message, ch string) *wsMessage {
-	text := msg.Text
-	for {
-		index := strings.Index(text, "<@")
-		if index < 0 {
-			break
-		}
-		end := strings.Index(text[index+2:], ">")
-		if end < 0 {
-			break
-		}
-		parts := strings.Split(text[index+2:index+2+end], "|")
-		if len(parts) == 2 {
-			if parts[0][0] == 'U' {
-				text = "@" + parts[1] + text[end+index+3:]
-			} else {
-				text = "#" + parts[1] + text[end+index+3:]
-			}
-		} else if len(parts) == 1 {
-			if len(parts[0]) > 0 {
-				if parts[0][0] == 'U' {
-					text = "@" + translateUser(parts[0]) + text[end+index+3:]
-				} else if parts[0][0] == 'C' {
-					text = "#" + translateChannel(parts[0]) + text[end+index+3:]
-				}
-			}
-		}
-	}
+	text := mrkdwn.ToPlainText(msg.Text, names{})
 	return &wsMessage{User: translateUser(msg.User), Channel: ch, Text: text, TS: formatTime(msg)}
 }
 
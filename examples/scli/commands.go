@@ -98,23 +98,20 @@ func userID(u string) string {
 }
 
 func channelName(ch string) string {
-	if ch == "" {
-		return ""
-	}
-	switch ch[0] {
-	case 'C':
+	switch slack.ChannelID(ch).Kind() {
+	case slack.PublicChannelKind:
 		for i := range info.Channels {
 			if info.Channels[i].ID == ch {
 				return info.Channels[i].Name
 			}
 		}
-	case 'G':
+	case slack.PrivateGroupKind:
 		for i := range info.Groups {
 			if info.Groups[i].ID == ch {
 				return info.Groups[i].Name
 			}
 		}
-	case 'D':
+	case slack.IMKind:
 		for i := range info.IMS {
 			if info.IMS[i].ID == ch {
 				return userNameByID(info.IMS[i].User)
@@ -211,16 +208,17 @@ func handleHistory(cmd string, parts []string) {
 	if id == "" {
 		fmt.Printf("%s not found\n", ch)
 	} else {
-		latest, oldest, count := "", "", 0
+		var latest, oldest slack.Timestamp
+		count := 0
 		if len(parts) > 1 {
 			for _, arg := range parts[1:] {
 				if len(arg) < 5 {
 					count, _ = strconv.Atoi(arg)
 				} else {
 					if latest == "" {
-						latest = arg
+						latest = slack.Timestamp(arg)
 					} else {
-						oldest = arg
+						oldest = slack.Timestamp(arg)
 					}
 				}
 			}
@@ -252,9 +250,10 @@ func handleInfo(cmd string, parts []string) {
 		var r slack.Response
 		var err error
 		if id != "" {
-			if id[0] == 'C' {
+			kind := slack.ChannelID(id).Kind()
+			if kind == slack.PublicChannelKind {
 				r, err = s.ChannelInfo(id)
-			} else if id[0] == 'G' {
+			} else if kind == slack.PrivateGroupKind {
 				r, err = s.GroupInfo(id)
 			} else {
 				fmt.Printf("IM with %s has no info\n", channelName(ch))
@@ -267,7 +266,7 @@ func handleInfo(cmd string, parts []string) {
 			fmt.Printf("Unable to retrieve info for %s - %s\n", ch, r.Error())
 		} else {
 			var b []byte
-			if id[0] == 'C' {
+			if slack.ChannelID(id).Kind() == slack.PublicChannelKind {
 				b, err = json.MarshalIndent(r.(*slack.ChannelResponse).Channel, "", "  ")
 			} else {
 				b, err = json.MarshalIndent(r.(*slack.GroupResponse).Group, "", "  ")
@@ -428,17 +427,18 @@ func handleRename(cmd string, parts []string) {
 func handlePurposeTopic(cmd string, parts []string) {
 	// TODO - handle errors
 	if len(parts) == 0 {
+		kind := slack.ChannelID(currChannelID).Kind()
 		switch cmd {
 		case "purpose", "c-purpose", "g-purpose":
-			if currChannelID[0] == 'C' {
+			if kind == slack.PublicChannelKind {
 				fmt.Println(findChannel(currChannelID).Purpose.Value)
-			} else if currChannelID[0] == 'G' {
+			} else if kind == slack.PrivateGroupKind {
 				fmt.Println(findGroup(currChannelID).Purpose.Value)
 			}
 		case "topic", "c-topic", "g-topic":
-			if currChannelID[0] == 'C' {
+			if kind == slack.PublicChannelKind {
 				fmt.Println(findChannel(currChannelID).Topic.Value)
-			} else if currChannelID[0] == 'G' {
+			} else if kind == slack.PrivateGroupKind {
 				fmt.Println(findGroup(currChannelID).Topic.Value)
 			}
 		}
@@ -78,7 +78,7 @@ func saveHistory(line *liner.State, stop chan bool) {
 func receiveMessages(line *liner.State, s *slack.Slack, in chan *slack.Message, stop chan bool) {
 	ticker := time.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
-	latest := make(map[string]string)
+	latest := make(map[string]slack.Timestamp)
 	for {
 		select {
 		case <-stop:
@@ -87,7 +87,7 @@ func receiveMessages(line *liner.State, s *slack.Slack, in chan *slack.Message,
 			for k, v := range latest {
 				s.Mark(k, v)
 			}
-			latest = make(map[string]string)
+			latest = make(map[string]slack.Timestamp)
 		case msg := <-in:
 			if msg == nil || msg.Type == "error" {
 				if msg == nil {
@@ -1,6 +1,7 @@
 package slack
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -105,10 +106,13 @@ type CommentResponse struct {
 	Comment Comment `json:"comment"`
 }
 
-// doUpload executes the API request for file upload
+// doUpload executes the API request for file upload bound to the given context
 // Returns the response if the status code is between 200 and 299
-func (s *Slack) doUpload(path, filename string, params url.Values, data io.Reader, result interface{}) error {
+func (s *Slack) doUpload(ctx context.Context, path, filename string, params url.Values, data io.Reader, result interface{}) error {
 	appendNotEmpty("token", s.token, params)
+	if err := s.wait(ctx, path, ""); err != nil {
+		return err
+	}
 	var t time.Time
 	if s.tracelog != nil {
 		t = time.Now()
@@ -122,6 +126,16 @@ func (s *Slack) doUpload(path, filename string, params url.Values, data io.Reade
 	fdct := writer.FormDataContentType()
 	// Read file errors from the channel
 	errChan := make(chan error, 1)
+	// Make sure the writer goroutine is released if the context is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			bodyReader.CloseWithError(ctx.Err())
+		case <-done:
+		}
+	}()
 	go func() {
 		defer bodyWriter.Close()
 		part, err := writer.CreateFormFile("file", filename)
@@ -143,7 +157,7 @@ func (s *Slack) doUpload(path, filename string, params url.Values, data io.Reade
 	}()
 
 	// create a HTTP request with our body, that contains our file
-	postReq, err := http.NewRequest("POST", s.url+path, bodyReader)
+	postReq, err := http.NewRequestWithContext(ctx, "POST", s.url+path, bodyReader)
 	if err != nil {
 		return err
 	}
@@ -154,7 +168,21 @@ func (s *Slack) doUpload(path, filename string, params url.Values, data io.Reade
 
 	// send our request off, get response and/or error
 	resp, err := s.c.Do(postReq)
-	if cerr := <-errChan; cerr != nil {
+	// Prefer the writer result so a context done right after a successful upload is not an error
+	var cerr error
+	select {
+	case cerr = <-errChan:
+	default:
+		select {
+		case cerr = <-errChan:
+		case <-ctx.Done():
+			cerr = ctx.Err()
+		}
+	}
+	if cerr != nil {
+		if err == nil {
+			resp.Body.Close()
+		}
 		return cerr
 	}
 
@@ -186,6 +214,11 @@ func (s *Slack) doUpload(path, filename string, params url.Values, data io.Reade
 
 // Upload a file to Slack optionally sharing it on given channels
 func (s *Slack) Upload(title, filetype, filename, initialComment string, channels []string, data io.Reader) (*FileUploadResponse, error) {
+	return s.UploadContext(context.Background(), title, filetype, filename, initialComment, channels, data)
+}
+
+// UploadContext is Upload with a custom context
+func (s *Slack) UploadContext(ctx context.Context, title, filetype, filename, initialComment string, channels []string, data io.Reader) (*FileUploadResponse, error) {
 	if filename == "" {
 		return nil, fmt.Errorf("You must specify the filename for the upload")
 	}
@@ -198,7 +231,7 @@ func (s *Slack) Upload(title, filetype, filename, initialComment string, channel
 		params.Set("channels", strings.Join(channels, ","))
 	}
 	r := &FileUploadResponse{}
-	err := s.doUpload("files.upload", filename, params, data, r)
+	err := s.doUpload(ctx, "files.upload", filename, params, data, r)
 	if err != nil {
 		return nil, err
 	}
@@ -207,6 +240,11 @@ func (s *Slack) Upload(title, filetype, filename, initialComment string, channel
 
 // FileList the files for the team
 func (s *Slack) FileList(user, tsFrom, tsTo string, types []string, count, page int) (*FileListResponse, error) {
+	return s.FileListContext(context.Background(), user, tsFrom, tsTo, types, count, page)
+}
+
+// FileListContext is FileList with a custom context
+func (s *Slack) FileListContext(ctx context.Context, user, tsFrom, tsTo string, types []string, count, page int) (*FileListResponse, error) {
 	params := url.Values{}
 	appendNotEmpty("user", user, params)
 	appendNotEmpty("ts_from", tsFrom, params)
@@ -219,7 +257,7 @@ func (s *Slack) FileList(user, tsFrom, tsTo string, types []string, count, page
 		appendNotEmpty("count", strconv.Itoa(count), params)
 	}
 	r := &FileListResponse{}
-	err := s.do("files.list", params, r)
+	err := s.do(ctx, "files.list", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -228,6 +266,11 @@ func (s *Slack) FileList(user, tsFrom, tsTo string, types []string, count, page
 
 // FileInfo command
 func (s *Slack) FileInfo(file string, count, page int) (*FileResponse, error) {
+	return s.FileInfoContext(context.Background(), file, count, page)
+}
+
+// FileInfoContext is FileInfo with a custom context
+func (s *Slack) FileInfoContext(ctx context.Context, file string, count, page int) (*FileResponse, error) {
 	params := url.Values{"file": {file}}
 	if page > 1 {
 		appendNotEmpty("page", strconv.Itoa(page), params)
@@ -236,7 +279,7 @@ func (s *Slack) FileInfo(file string, count, page int) (*FileResponse, error) {
 		appendNotEmpty("count", strconv.Itoa(count), params)
 	}
 	r := &FileResponse{}
-	err := s.do("files.info", params, r)
+	err := s.do(ctx, "files.info", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -245,9 +288,14 @@ func (s *Slack) FileInfo(file string, count, page int) (*FileResponse, error) {
 
 // FileAddComment to a given file
 func (s *Slack) FileAddComment(file, comment string, setActive bool) (*CommentResponse, error) {
+	return s.FileAddCommentContext(context.Background(), file, comment, setActive)
+}
+
+// FileAddCommentContext is FileAddComment with a custom context
+func (s *Slack) FileAddCommentContext(ctx context.Context, file, comment string, setActive bool) (*CommentResponse, error) {
 	params := url.Values{"file": {file}, "comment": {comment}, "set_active": {strconv.FormatBool(setActive)}}
 	r := &CommentResponse{}
-	err := s.do("files.comments.add", params, r)
+	err := s.do(ctx, "files.comments.add", params, r)
 	if err != nil {
 		return nil, err
 	}
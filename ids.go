@@ -0,0 +1,226 @@
+package slack
+
+import (
+	"net/url"
+	"strings"
+)
+
+// IDKind is the kind of object an ID refers to based on its prefix
+type IDKind int
+
+// The ID kinds
+const (
+	UnknownKind        IDKind = iota // Empty or unrecognized ID
+	PublicChannelKind                // C - public channels and newer private channels
+	PrivateGroupKind                 // G - legacy private channels and multiparty IMs
+	IMKind                           // D - direct messages
+	MPIMKind                         // Multiparty IMs - only from Conversation.Kind as they share the G prefix
+	SharedChannelKind                // Channels shared with other teams - only from Conversation.Kind as they share the C prefix
+	UserKind                         // U - users
+	EnterpriseUserKind               // W - users of an Enterprise Grid organization
+	BotKind                          // B - bots
+	TeamKind                         // T - teams
+	EnterpriseKind                   // E - Enterprise Grid organizations
+	FileKind                         // F - files
+)
+
+var idKindNames = map[IDKind]string{
+	UnknownKind:        "unknown",
+	PublicChannelKind:  "channel",
+	PrivateGroupKind:   "group",
+	IMKind:             "im",
+	MPIMKind:           "mpim",
+	SharedChannelKind:  "shared",
+	UserKind:           "user",
+	EnterpriseUserKind: "enterprise_user",
+	BotKind:            "bot",
+	TeamKind:           "team",
+	EnterpriseKind:     "enterprise",
+	FileKind:           "file",
+}
+
+func (k IDKind) String() string {
+	return idKindNames[k]
+}
+
+// idKinds maps the ID prefixes to their kind
+var idKinds = map[byte]IDKind{
+	'C': PublicChannelKind,
+	'G': PrivateGroupKind,
+	'D': IMKind,
+	'U': UserKind,
+	'W': EnterpriseUserKind,
+	'B': BotKind,
+	'T': TeamKind,
+	'E': EnterpriseKind,
+	'F': FileKind,
+}
+
+// kindOf returns the kind of the ID if it is well formed - an upper case prefix followed by
+// upper case letters and digits
+func kindOf(id string) IDKind {
+	if len(id) < 2 || strings.Trim(id, "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789") != "" {
+		return UnknownKind
+	}
+	return idKinds[id[0]]
+}
+
+// ChannelID is the ID of a channel, group, IM or MPIM
+type ChannelID string
+
+// Kind returns PublicChannelKind, PrivateGroupKind or IMKind. MPIMs and shared channels cannot be
+// told apart by their ID alone so use Conversation.Kind for those.
+func (id ChannelID) Kind() IDKind {
+	switch k := kindOf(string(id)); k {
+	case PublicChannelKind, PrivateGroupKind, IMKind:
+		return k
+	}
+	return UnknownKind
+}
+
+// Valid returns true if the ID is a well formed channel, group or IM ID
+func (id ChannelID) Valid() bool {
+	return id.Kind() != UnknownKind
+}
+
+// IsZero returns true for an empty ID
+func (id ChannelID) IsZero() bool {
+	return id == ""
+}
+
+func (id ChannelID) String() string {
+	return string(id)
+}
+
+// UserID is the ID of a user
+type UserID string
+
+// Kind returns UserKind or EnterpriseUserKind
+func (id UserID) Kind() IDKind {
+	switch k := kindOf(string(id)); k {
+	case UserKind, EnterpriseUserKind:
+		return k
+	}
+	return UnknownKind
+}
+
+// Valid returns true if the ID is a well formed user ID
+func (id UserID) Valid() bool {
+	return id.Kind() != UnknownKind
+}
+
+// IsZero returns true for an empty ID
+func (id UserID) IsZero() bool {
+	return id == ""
+}
+
+func (id UserID) String() string {
+	return string(id)
+}
+
+// TeamID is the ID of a team or an Enterprise Grid organization
+type TeamID string
+
+// Kind returns TeamKind or EnterpriseKind
+func (id TeamID) Kind() IDKind {
+	switch k := kindOf(string(id)); k {
+	case TeamKind, EnterpriseKind:
+		return k
+	}
+	return UnknownKind
+}
+
+// Valid returns true if the ID is a well formed team ID
+func (id TeamID) Valid() bool {
+	return id.Kind() != UnknownKind
+}
+
+// IsZero returns true for an empty ID
+func (id TeamID) IsZero() bool {
+	return id == ""
+}
+
+func (id TeamID) String() string {
+	return string(id)
+}
+
+// FileID is the ID of a file
+type FileID string
+
+// Kind returns FileKind
+func (id FileID) Kind() IDKind {
+	if k := kindOf(string(id)); k == FileKind {
+		return k
+	}
+	return UnknownKind
+}
+
+// Valid returns true if the ID is a well formed file ID
+func (id FileID) Valid() bool {
+	return id.Kind() != UnknownKind
+}
+
+// IsZero returns true for an empty ID
+func (id FileID) IsZero() bool {
+	return id == ""
+}
+
+func (id FileID) String() string {
+	return string(id)
+}
+
+// BotID is the ID of a bot
+type BotID string
+
+// Kind returns BotKind
+func (id BotID) Kind() IDKind {
+	if k := kindOf(string(id)); k == BotKind {
+		return k
+	}
+	return UnknownKind
+}
+
+// Valid returns true if the ID is a well formed bot ID
+func (id BotID) Valid() bool {
+	return id.Kind() != UnknownKind
+}
+
+// IsZero returns true for an empty ID
+func (id BotID) IsZero() bool {
+	return id == ""
+}
+
+func (id BotID) String() string {
+	return string(id)
+}
+
+// Kind returns the kind of the conversation using its flags which, unlike the ID, tell MPIMs
+// and shared channels apart
+func (c *Conversation) Kind() IDKind {
+	switch {
+	case c.IsIM:
+		return IMKind
+	case c.IsMPIM:
+		return MPIMKind
+	case c.IsShared || c.IsExtShared || c.IsOrgShared:
+		return SharedChannelKind
+	case c.IsPrivate || c.IsGroup:
+		return PrivateGroupKind
+	case c.IsChannel:
+		return PublicChannelKind
+	}
+	return ChannelID(c.ID).Kind()
+}
+
+// requiredIDs are the parameters which must not be empty
+var requiredIDs = []string{"channel", "user", "file"}
+
+// checkIDs returns an error instead of calling Slack when a required ID parameter is empty
+func checkIDs(path string, params url.Values) error {
+	for _, name := range requiredIDs {
+		if v, ok := params[name]; ok && (len(v) == 0 || v[0] == "") {
+			return newError("invalid_id", "The %s ID for %s is empty", name, path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package slack
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		id   string
+		want IDKind
+	}{
+		{"", UnknownKind},
+		{"C", UnknownKind},
+		{"C024BE91L", PublicChannelKind},
+		{"G024BE91L", PrivateGroupKind},
+		{"D024BE91L", IMKind},
+		{"U024BE7LH", UserKind},
+		{"W012A3CDE", EnterpriseUserKind},
+		{"B024BE7LH", BotKind},
+		{"T024BE7LD", TeamKind},
+		{"E12345678", EnterpriseKind},
+		{"F024BE91L", FileKind},
+		{"c024be91l", UnknownKind},
+		{"C024be91l", UnknownKind},
+		{"X024BE91L", UnknownKind},
+		{"#general", UnknownKind},
+		{"C024 BE91", UnknownKind},
+	}
+	for _, tt := range tests {
+		if got := kindOf(tt.id); got != tt.want {
+			t.Errorf("kindOf(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestIDKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		kind IDKind
+		want IDKind
+	}{
+		{"channel", ChannelID("C024BE91L").Kind(), PublicChannelKind},
+		{"group", ChannelID("G024BE91L").Kind(), PrivateGroupKind},
+		{"im", ChannelID("D024BE91L").Kind(), IMKind},
+		{"user as channel", ChannelID("U024BE7LH").Kind(), UnknownKind},
+		{"empty channel", ChannelID("").Kind(), UnknownKind},
+		{"user", UserID("U024BE7LH").Kind(), UserKind},
+		{"enterprise user", UserID("W012A3CDE").Kind(), EnterpriseUserKind},
+		{"lowercase user", UserID("u024be7lh").Kind(), UnknownKind},
+		{"channel as user", UserID("C024BE91L").Kind(), UnknownKind},
+		{"team", TeamID("T024BE7LD").Kind(), TeamKind},
+		{"enterprise", TeamID("E12345678").Kind(), EnterpriseKind},
+		{"file", FileID("F024BE91L").Kind(), FileKind},
+		{"bot", BotID("B024BE7LH").Kind(), BotKind},
+		{"mpim", (&Conversation{BaseChannel: BaseChannel{ID: "G024BE91L"}, IsMPIM: true}).Kind(), MPIMKind},
+		{"shared", (&Conversation{BaseChannel: BaseChannel{ID: "C024BE91L"}, IsChannel: true, IsShared: true}).Kind(), SharedChannelKind},
+		{"conversation by ID", (&Conversation{BaseChannel: BaseChannel{ID: "D024BE91L"}}).Kind(), IMKind},
+	}
+	for _, tt := range tests {
+		if tt.kind != tt.want {
+			t.Errorf("%s kind = %v, want %v", tt.name, tt.kind, tt.want)
+		}
+	}
+	if !ChannelID("").IsZero() || ChannelID("").Valid() || !UserID("W012A3CDE").Valid() {
+		t.Error("IsZero or Valid is wrong")
+	}
+}
+
+func TestCheckIDs(t *testing.T) {
+	tests := []struct {
+		params url.Values
+		ok     bool
+	}{
+		{url.Values{}, true},
+		{url.Values{"channel": {"C024BE91L"}, "user": {"W012A3CDE"}}, true},
+		{url.Values{"channel": {"#general"}}, true},
+		{url.Values{"channel": {""}}, false},
+		{url.Values{"user": {""}}, false},
+		{url.Values{"file": {}}, false},
+		{url.Values{"name": {""}}, true},
+	}
+	for _, tt := range tests {
+		err := checkIDs("conversations.info", tt.params)
+		if (err == nil) != tt.ok {
+			t.Errorf("checkIDs(%v) = %v, want ok %v", tt.params, err, tt.ok)
+			continue
+		}
+		if e, isErr := err.(*Error); err != nil && (!isErr || e.ID != "invalid_id") {
+			t.Errorf("checkIDs(%v) = %v, want invalid_id", tt.params, err)
+		}
+	}
+}
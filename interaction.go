@@ -0,0 +1,298 @@
+package slack
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Interaction payload types - see https://api.slack.com/reference/interaction-payloads
+const (
+	InteractionBlockActions   = "block_actions"
+	InteractionViewSubmission = "view_submission"
+	InteractionViewClosed     = "view_closed"
+	InteractionShortcut       = "shortcut"
+	InteractionMessageAction  = "message_action"
+)
+
+// Response actions for view submissions
+const (
+	ResponseActionErrors = "errors"
+	ResponseActionUpdate = "update"
+	ResponseActionPush   = "push"
+	ResponseActionClear  = "clear"
+)
+
+// InteractionUser is the user who interacted
+type InteractionUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	TeamID   string `json:"team_id"`
+}
+
+// InteractionTeam is the team where the interaction happened
+type InteractionTeam struct {
+	ID     string `json:"id"`
+	Domain string `json:"domain"`
+}
+
+// InteractionChannel is the channel where the interaction happened
+type InteractionChannel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// InteractionContainer is the surface the interaction originated from
+type InteractionContainer struct {
+	Type         string    `json:"type"`
+	ViewID       string    `json:"view_id,omitempty"`
+	MessageTs    Timestamp `json:"message_ts,omitempty"`
+	ChannelID    string    `json:"channel_id,omitempty"`
+	IsEphemeral  bool      `json:"is_ephemeral,omitempty"`
+	ThreadTs     Timestamp `json:"thread_ts,omitempty"`
+	AttachmentID int       `json:"attachment_id,omitempty"`
+}
+
+// BlockAction is an action on an interactive block element, also used for the input values of a view
+type BlockAction struct {
+	ActionID             string          `json:"action_id"`
+	BlockID              string          `json:"block_id"`
+	Type                 string          `json:"type"`
+	Value                string          `json:"value,omitempty"`
+	ActionTs             Timestamp       `json:"action_ts,omitempty"`
+	Text                 *TextObject     `json:"text,omitempty"`
+	SelectedOption       *OptionObject   `json:"selected_option,omitempty"`
+	SelectedOptions      []*OptionObject `json:"selected_options,omitempty"`
+	SelectedDate         string          `json:"selected_date,omitempty"`
+	SelectedTime         string          `json:"selected_time,omitempty"`
+	SelectedUser         string          `json:"selected_user,omitempty"`
+	SelectedUsers        []string        `json:"selected_users,omitempty"`
+	SelectedChannel      string          `json:"selected_channel,omitempty"`
+	SelectedChannels     []string        `json:"selected_channels,omitempty"`
+	SelectedConversation string          `json:"selected_conversation,omitempty"`
+}
+
+// ViewState holds the values of the input blocks of a view by block ID and action ID
+type ViewState struct {
+	Values map[string]map[string]BlockAction `json:"values"`
+}
+
+// View is a modal or home tab view - see https://api.slack.com/reference/surfaces/views
+type View struct {
+	ID                 string      `json:"id"`
+	TeamID             string      `json:"team_id"`
+	Type               string      `json:"type"`
+	Title              *TextObject `json:"title,omitempty"`
+	Submit             *TextObject `json:"submit,omitempty"`
+	Close              *TextObject `json:"close,omitempty"`
+	Blocks             Blocks      `json:"blocks,omitempty"`
+	PrivateMetadata    string      `json:"private_metadata,omitempty"`
+	CallbackID         string      `json:"callback_id,omitempty"`
+	State              ViewState   `json:"state"`
+	Hash               string      `json:"hash,omitempty"`
+	ClearOnClose       bool        `json:"clear_on_close,omitempty"`
+	NotifyOnClose      bool        `json:"notify_on_close,omitempty"`
+	RootViewID         string      `json:"root_view_id,omitempty"`
+	PreviousViewID     string      `json:"previous_view_id,omitempty"`
+	AppID              string      `json:"app_id,omitempty"`
+	ExternalID         string      `json:"external_id,omitempty"`
+	BotID              string      `json:"bot_id,omitempty"`
+	AppInstalledTeamID string      `json:"app_installed_team_id,omitempty"`
+}
+
+// InteractionResponseURL is a response URL provided with view submissions
+type InteractionResponseURL struct {
+	BlockID     string `json:"block_id"`
+	ActionID    string `json:"action_id"`
+	ChannelID   string `json:"channel_id"`
+	ResponseURL string `json:"response_url"`
+}
+
+// InteractionCallback is the payload sent when a user interacts with the app
+type InteractionCallback struct {
+	Type         string                   `json:"type"`
+	Token        string                   `json:"token"`
+	CallbackID   string                   `json:"callback_id,omitempty"` // For shortcuts and message actions
+	TriggerID    string                   `json:"trigger_id"`
+	ResponseURL  string                   `json:"response_url,omitempty"`
+	ActionTs     Timestamp                `json:"action_ts,omitempty"`
+	APIAppID     string                   `json:"api_app_id"`
+	Team         InteractionTeam          `json:"team"`
+	User         InteractionUser          `json:"user"`
+	Channel      InteractionChannel       `json:"channel"`
+	Container    InteractionContainer     `json:"container"`
+	Message      *Message                 `json:"message,omitempty"`
+	Actions      []BlockAction            `json:"actions,omitempty"`
+	View         *View                    `json:"view,omitempty"`
+	IsCleared    bool                     `json:"is_cleared,omitempty"`
+	ResponseURLs []InteractionResponseURL `json:"response_urls,omitempty"`
+}
+
+// ParseInteraction decodes the JSON interaction payload
+func ParseInteraction(payload []byte) (*InteractionCallback, error) {
+	cb := &InteractionCallback{}
+	if err := json.Unmarshal(payload, cb); err != nil {
+		return nil, err
+	}
+	return cb, nil
+}
+
+// Interaction decodes the payload of an interactive Socket Mode envelope
+func (req *SocketModeRequest) Interaction() (*InteractionCallback, error) {
+	return ParseInteraction(req.Payload)
+}
+
+// ViewSubmissionResponse is the response to a view submission - see https://api.slack.com/surfaces/modals/using#responding_to_submissions
+type ViewSubmissionResponse struct {
+	ResponseAction string            `json:"response_action"`
+	Errors         map[string]string `json:"errors,omitempty"` // Error messages by block ID
+	View           interface{}       `json:"view,omitempty"`   // The view to update or push
+}
+
+// ViewSubmissionErrors returns a response showing the errors by block ID
+func ViewSubmissionErrors(errors map[string]string) *ViewSubmissionResponse {
+	return &ViewSubmissionResponse{ResponseAction: ResponseActionErrors, Errors: errors}
+}
+
+// ViewSubmissionUpdate returns a response replacing the current view
+func ViewSubmissionUpdate(view interface{}) *ViewSubmissionResponse {
+	return &ViewSubmissionResponse{ResponseAction: ResponseActionUpdate, View: view}
+}
+
+// ViewSubmissionPush returns a response pushing a new view on the stack
+func ViewSubmissionPush(view interface{}) *ViewSubmissionResponse {
+	return &ViewSubmissionResponse{ResponseAction: ResponseActionPush, View: view}
+}
+
+// ViewSubmissionClear returns a response closing all the views
+func ViewSubmissionClear() *ViewSubmissionResponse {
+	return &ViewSubmissionResponse{ResponseAction: ResponseActionClear}
+}
+
+// InteractionHandlerFunc handles an interaction and returns the optional response body,
+// for example a *ViewSubmissionResponse for view submissions
+type InteractionHandlerFunc func(cb *InteractionCallback) (interface{}, error)
+
+// InteractionHandler is an http.Handler receiving the interaction payloads. It verifies
+// the request signature, decodes the payload form field and routes block actions by
+// action_id and views, shortcuts and message actions by callback_id.
+type InteractionHandler struct {
+	Verifier
+	ErrorLog  *log.Logger // Optional logger to write rejected requests and handler errors to
+	mutex     sync.RWMutex
+	actions   map[string]InteractionHandlerFunc // Block action handlers by action ID
+	callbacks map[string]InteractionHandlerFunc // View, shortcut and message action handlers by callback ID
+}
+
+// NewInteractionHandler creates a handler for the given signing secret. With an empty secret
+// every request is rejected with ErrNoSigningSecret.
+func NewInteractionHandler(signingSecret string) *InteractionHandler {
+	return &InteractionHandler{
+		Verifier:  Verifier{SigningSecret: signingSecret},
+		actions:   make(map[string]InteractionHandlerFunc),
+		callbacks: make(map[string]InteractionHandlerFunc),
+	}
+}
+
+// OnAction registers the handler for block actions with the given action ID
+func (h *InteractionHandler) OnAction(actionID string, fn InteractionHandlerFunc) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.actions == nil {
+		h.actions = make(map[string]InteractionHandlerFunc)
+	}
+	h.actions[actionID] = fn
+}
+
+// OnCallback registers the handler for view submissions, closed views, shortcuts and
+// message actions with the given callback ID
+func (h *InteractionHandler) OnCallback(callbackID string, fn InteractionHandlerFunc) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.callbacks == nil {
+		h.callbacks = make(map[string]InteractionHandlerFunc)
+	}
+	h.callbacks[callbackID] = fn
+}
+
+// errorf logs to the error log if defined
+func (h *InteractionHandler) errorf(format string, args ...interface{}) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, args...)
+	}
+}
+
+// Dispatch routes the interaction to the matching handlers and returns the response
+func (h *InteractionHandler) Dispatch(cb *InteractionCallback) (interface{}, error) {
+	var handlers []InteractionHandlerFunc
+	h.mutex.RLock()
+	switch cb.Type {
+	case InteractionBlockActions:
+		for _, a := range cb.Actions {
+			if fn, ok := h.actions[a.ActionID]; ok {
+				handlers = append(handlers, fn)
+			}
+		}
+	case InteractionViewSubmission, InteractionViewClosed:
+		if cb.View != nil {
+			if fn, ok := h.callbacks[cb.View.CallbackID]; ok {
+				handlers = append(handlers, fn)
+			}
+		}
+	default:
+		if fn, ok := h.callbacks[cb.CallbackID]; ok {
+			handlers = append(handlers, fn)
+		}
+	}
+	h.mutex.RUnlock()
+	var resp interface{}
+	for _, fn := range handlers {
+		r, err := fn(cb)
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			resp = r
+		}
+	}
+	return resp, nil
+}
+
+// ServeHTTP handles the interaction request
+func (h *InteractionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := h.Verify(r)
+	if err != nil {
+		h.errorf("Rejected interaction request - %v\n", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cb, err := ParseInteraction([]byte(form.Get("payload")))
+	if err != nil {
+		h.errorf("Error unmarshaling interaction payload - %s\n", form.Get("payload"))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp, err := h.Dispatch(cb)
+	if err != nil {
+		h.errorf("Error handling %s interaction - %v\n", cb.Type, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if resp == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	writeJSON(w, resp)
+}
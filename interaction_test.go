@@ -0,0 +1,24 @@
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestInteractionHandlerEmptySecret(t *testing.T) {
+	called := false
+	h := NewInteractionHandler("")
+	h.OnCallback("approve", func(*InteractionCallback) (interface{}, error) {
+		called = true
+		return nil, nil
+	})
+	body := url.Values{"payload": {`{"type":"shortcut","callback_id":"approve"}`}}.Encode()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest("", body, time.Now()))
+	if w.Code != http.StatusUnauthorized || called {
+		t.Errorf("empty secret status = %d, called = %v, want %d and no call", w.Code, called, http.StatusUnauthorized)
+	}
+}
@@ -1,9 +1,6 @@
 package slack
 
 import (
-	"errors"
-	"strconv"
-	"strings"
 	"time"
 )
 
@@ -16,8 +13,10 @@ type TypedMessage interface {
 
 // baseTypeMessage is used to parse the message type before we parse the entire message
 type baseTypeMessage struct {
-	Type  string `json:"type"`
-	Error struct {
+	Type    string `json:"type"`
+	ReplyTo int    `json:"reply_to,omitempty"`
+	Subtype string `json:"subtype,omitempty"`
+	Error   struct {
 		Code int    `json:"code"`
 		Msg  string `json:"msg"`
 	} `json:"error,omitempty"`
@@ -25,28 +24,28 @@ type baseTypeMessage struct {
 
 // Message holds the information about incoming messages in the RTM
 type Message struct {
-	Type      string `json:"type"`
-	Channel   string `json:"channel"`
-	User      string `json:"user"`
-	Text      string `json:"text"`
-	Timestamp string `json:"ts"`
-	Hidden    bool   `json:"hidden,omitempty"`
-	Subtype   string `json:"subtype,omitempty"`
+	Type      string    `json:"type"`
+	Channel   string    `json:"channel"`
+	User      string    `json:"user"`
+	Text      string    `json:"text"`
+	Timestamp Timestamp `json:"ts"`
+	Hidden    bool      `json:"hidden,omitempty"`
+	Subtype   string    `json:"subtype,omitempty"`
 	Edited    struct {
-		User      string `json:"user"`
-		Timestamp string `json:"ts"`
+		User      string    `json:"user"`
+		Timestamp Timestamp `json:"ts"`
 	} `json:"edited,omitempty"`
 	Message struct {
-		Type      string `json:"type"`
-		User      string `json:"user"`
-		Text      string `json:"text"`
-		Timestamp string `json:"ts"`
+		Type      string    `json:"type"`
+		User      string    `json:"user"`
+		Text      string    `json:"text"`
+		Timestamp Timestamp `json:"ts"`
 		Edited    struct {
-			User      string `json:"user"`
-			Timestamp string `json:"ts"`
+			User      string    `json:"user"`
+			Timestamp Timestamp `json:"ts"`
 		} `json:"edited,omitempty"`
 	} `json:"message,omitempty"`
-	DeletedTS      string      `json:"deleted_ts,omitempty"`
+	DeletedTS      Timestamp   `json:"deleted_ts,omitempty"`
 	Topic          string      `json:"topic,omitempty"`
 	Purpose        string      `json:"purpose,omitempty"`
 	Name           string      `json:"name,omitempty"`
@@ -56,19 +55,60 @@ type Message struct {
 	File           File        `json:"file,omitempty"`
 	Comment        Comment     `json:"comment,omitempty"`
 	Reactions      []Reaction  `json:"reactions,omitempty"`
+	Blocks         Blocks      `json:"blocks,omitempty"`
 	Presence       string      `json:"presence,omitempty"`
 	Value          interface{} `json:"value,omitempty"`
 	Plan           string      `json:"plan,omitempty"`
 	URL            string      `json:"url,omitempty"`
 	Domain         string      `json:"domain,omitempty"`
 	EmailDomain    string      `json:"email_domain,omitempty"`
-	EventTimestamp string      `json:"event_ts,omitempty"`
+	EventTimestamp Timestamp   `json:"event_ts,omitempty"`
 	Error          struct {
 		Code       int    `json:"code"`
 		Msg        string `json:"msg"`
 		Unmarshall bool   `json:"unmarshall"` // Is this an unmarshall error and not request error
 	} `json:"error,omitempty"`
 	Context interface{} `json:"context,omitempty"` // A piece of data that will be passed with every message from RTMStart
+	// Thread details - see https://api.slack.com/messaging/retrieving#threading
+	ThreadTimestamp Timestamp      `json:"thread_ts,omitempty"`      // The timestamp of the thread parent
+	ParentUserID    string         `json:"parent_user_id,omitempty"` // The author of the thread parent on replies
+	ReplyCount      int            `json:"reply_count,omitempty"`    // The number of replies on the thread parent
+	ReplyUsersCount int            `json:"reply_users_count,omitempty"`
+	ReplyUsers      []string       `json:"reply_users,omitempty"`
+	LatestReply     Timestamp      `json:"latest_reply,omitempty"` // The timestamp of the latest reply
+	Replies         []MessageReply `json:"replies,omitempty"`
+	Root            *Message       `json:"root,omitempty"` // The thread parent of thread_broadcast messages
+}
+
+// MessageReply is a reply listed on the parent message of a thread
+type MessageReply struct {
+	User      string    `json:"user"`
+	Timestamp Timestamp `json:"ts"`
+}
+
+// IsThreadReply returns true if the message is a reply in a thread, including broadcast replies
+func (m *Message) IsThreadReply() bool {
+	return m.ThreadTimestamp != "" && m.ThreadTimestamp != m.Timestamp
+}
+
+// IsThreadParent returns true if the message started a thread
+func (m *Message) IsThreadParent() bool {
+	return m.ThreadTimestamp != "" && m.ThreadTimestamp == m.Timestamp
+}
+
+// ThreadID returns the timestamp to reply in the thread of the message. For messages outside
+// a thread it is the message timestamp so the reply starts a new thread.
+func (m *Message) ThreadID() Timestamp {
+	if m.ThreadTimestamp != "" {
+		return m.ThreadTimestamp
+	}
+	return m.Timestamp
+}
+
+// ChannelKind returns the kind of the channel the message belongs to by its ID. MPIMs are
+// reported as PrivateGroupKind - see Conversation.Kind.
+func (m *Message) ChannelKind() IDKind {
+	return ChannelID(m.Channel).Kind()
 }
 
 // MessageType of message is returned
@@ -98,15 +138,13 @@ type UserEvent struct {
 	User User   `json:"user"`
 }
 
-// TimestampToTime converter
+// TimestampToTime converter with microsecond precision.
+//
+// Deprecated: use Timestamp.Time.
 func TimestampToTime(timestamp string) (time.Time, error) {
-	parts := strings.Split(timestamp, ".")
-	if len(parts) > 0 && parts[0] != "" {
-		sec, err := strconv.ParseInt(parts[0], 10, 64)
-		if err != nil {
-			return time.Time{}, err
-		}
-		return time.Unix(sec, 0), nil
+	sec, usec, err := Timestamp(timestamp).parts()
+	if err != nil {
+		return time.Time{}, err
 	}
-	return time.Time{}, errors.New("Invalid timestamp")
+	return time.Unix(sec, usec*1000), nil
 }
@@ -0,0 +1,124 @@
+// Package mrkdwn formats and parses the Slack mrkdwn message text - see https://api.slack.com/reference/surfaces/formatting
+package mrkdwn
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Special mentions notifying a group of users
+const (
+	Here     = "<!here>"
+	Channel  = "<!channel>"
+	Everyone = "<!everyone>"
+)
+
+// Date formatting tokens - see https://api.slack.com/reference/surfaces/formatting#date-formatting
+const (
+	DateNum         = "{date_num}"
+	Date            = "{date}"
+	DateShort       = "{date_short}"
+	DateLong        = "{date_long}"
+	DatePretty      = "{date_pretty}"
+	DateShortPretty = "{date_short_pretty}"
+	DateLongPretty  = "{date_long_pretty}"
+	Time            = "{time}"
+	TimeSecs        = "{time_secs}"
+)
+
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+var unescaper = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">")
+
+// Escape escapes the control characters &, < and > so the text is shown as is
+func Escape(text string) string {
+	return escaper.Replace(text)
+}
+
+// Unescape reverses Escape
+func Unescape(text string) string {
+	return unescaper.Replace(text)
+}
+
+// User returns a mention of the user with the given ID
+func User(id string) string {
+	return "<@" + id + ">"
+}
+
+// ChannelLink returns a link to the channel with the given ID
+func ChannelLink(id string) string {
+	return "<#" + id + ">"
+}
+
+// UserGroup returns a mention of the user group with the given ID
+func UserGroup(id string) string {
+	return "<!subteam^" + id + ">"
+}
+
+// Link returns a link to the URL with an optional label
+func Link(url, label string) string {
+	url = strings.NewReplacer("<", "%3C", ">", "%3E", "|", "%7C").Replace(url)
+	if label == "" {
+		return "<" + url + ">"
+	}
+	return "<" + url + "|" + Escape(label) + ">"
+}
+
+// Email returns a mailto link for the address
+func Email(address string) string {
+	return Link("mailto:"+address, address)
+}
+
+// FormatDate returns a date shown in the local time of the reader. The format holds the
+// date tokens, for example "{date_short} at {time}", and the fallback is shown by clients
+// which do not support dates.
+func FormatDate(t time.Time, format, fallback string) string {
+	return FormatDateLink(t, format, "", fallback)
+}
+
+// FormatDateLink is FormatDate with the date linking to the given URL
+func FormatDateLink(t time.Time, format, link, fallback string) string {
+	s := "<!date^" + strconv.FormatInt(t.Unix(), 10) + "^" + Escape(format)
+	if link != "" {
+		s += "^" + link
+	}
+	if fallback == "" {
+		fallback = t.UTC().Format(time.RFC1123)
+	}
+	return s + "|" + Escape(fallback) + ">"
+}
+
+// Bold returns the escaped text in bold
+func Bold(text string) string {
+	return "*" + Escape(text) + "*"
+}
+
+// Italic returns the escaped text in italics
+func Italic(text string) string {
+	return "_" + Escape(text) + "_"
+}
+
+// Strike returns the escaped text with a strikethrough
+func Strike(text string) string {
+	return "~" + Escape(text) + "~"
+}
+
+// Code returns the escaped text as inline code
+func Code(text string) string {
+	return "`" + strings.Replace(Escape(text), "`", "'", -1) + "`"
+}
+
+// CodeBlock returns the escaped text as a code block
+func CodeBlock(text string) string {
+	return "```\n" + strings.Replace(Escape(text), "```", "'''", -1) + "\n```"
+}
+
+// Quote returns the escaped text as a quote
+func Quote(text string) string {
+	lines := strings.Split(Escape(text), "\n")
+	for i := range lines {
+		lines[i] = "> " + lines[i]
+	}
+	return strings.Join(lines, "\n")
+}
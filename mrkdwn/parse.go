@@ -0,0 +1,198 @@
+package mrkdwn
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SegmentType is the type of a message text segment
+type SegmentType int
+
+// The segment types
+const (
+	TextSegment      SegmentType = iota // Plain text which may hold styles like *bold*
+	UserSegment                         // <@U123> or <@U123|name>
+	ChannelSegment                      // <#C123> or <#C123|name>
+	UserGroupSegment                    // <!subteam^S123|@group>
+	SpecialSegment                      // <!here>, <!channel> or <!everyone>
+	LinkSegment                         // <http://example.com|label>
+	DateSegment                         // <!date^1392734382^{date_short}|fallback>
+)
+
+// Segment is a part of the message text
+type Segment struct {
+	Type   SegmentType
+	Text   string    // The unescaped text of text segments
+	ID     string    // The user, channel or user group ID or the special mention (here, channel, everyone)
+	URL    string    // The URL of links and the optional link of dates
+	Label  string    // The optional label of the token or the fallback of dates
+	Time   time.Time // The time of dates
+	Format string    // The format of dates
+	Raw    string    // The raw text of the segment
+}
+
+// Segments is a parsed message text
+type Segments []Segment
+
+// Parse tokenizes the message text into text segments and the <...> tokens
+func Parse(text string) Segments {
+	var segments Segments
+	for text != "" {
+		start := strings.IndexByte(text, '<')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(text[start:], '>')
+		if end < 0 {
+			break
+		}
+		end += start
+		if start > 0 {
+			segments = append(segments, textSegment(text[:start]))
+		}
+		segments = append(segments, parseToken(text[start:end+1]))
+		text = text[end+1:]
+	}
+	if text != "" {
+		segments = append(segments, textSegment(text))
+	}
+	return segments
+}
+
+// textSegment returns a segment for plain text
+func textSegment(raw string) Segment {
+	return Segment{Type: TextSegment, Text: Unescape(raw), Raw: raw}
+}
+
+// parseToken parses a single <...> token
+func parseToken(raw string) Segment {
+	content := raw[1 : len(raw)-1]
+	label := ""
+	if i := strings.IndexByte(content, '|'); i >= 0 {
+		content, label = content[:i], Unescape(content[i+1:])
+	}
+	s := Segment{Label: label, Raw: raw}
+	switch {
+	case strings.HasPrefix(content, "@"):
+		s.Type, s.ID = UserSegment, content[1:]
+	case strings.HasPrefix(content, "#"):
+		s.Type, s.ID = ChannelSegment, content[1:]
+	case strings.HasPrefix(content, "!subteam^"):
+		s.Type, s.ID = UserGroupSegment, content[len("!subteam^"):]
+	case strings.HasPrefix(content, "!date^"):
+		parts := strings.SplitN(content[len("!date^"):], "^", 3)
+		ts, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || len(parts) < 2 {
+			return textSegment(raw)
+		}
+		s.Type, s.Time, s.Format = DateSegment, time.Unix(ts, 0), Unescape(parts[1])
+		if len(parts) == 3 {
+			s.URL = parts[2]
+		}
+	case strings.HasPrefix(content, "!"):
+		s.Type, s.ID = SpecialSegment, content[1:]
+	case content == "":
+		return textSegment(raw)
+	default:
+		s.Type, s.URL = LinkSegment, Unescape(content)
+	}
+	return s
+}
+
+// Resolver returns the names of users and channels for mentions without a label
+type Resolver interface {
+	UserName(id string) string
+	ChannelName(id string) string
+}
+
+// Names is a Resolver backed by maps of names by ID
+type Names struct {
+	Users    map[string]string
+	Channels map[string]string
+}
+
+// UserName returns the name of the user or an empty string if not known
+func (n *Names) UserName(id string) string {
+	return n.Users[id]
+}
+
+// ChannelName returns the name of the channel or an empty string if not known
+func (n *Names) ChannelName(id string) string {
+	return n.Channels[id]
+}
+
+// name returns the text of a mention or link segment
+func (s *Segment) name(r Resolver) string {
+	switch s.Type {
+	case UserSegment, ChannelSegment:
+		name := s.Label
+		if name == "" && r != nil {
+			if s.Type == UserSegment {
+				name = r.UserName(s.ID)
+			} else {
+				name = r.ChannelName(s.ID)
+			}
+		}
+		if name == "" {
+			name = s.ID
+		}
+		if s.Type == UserSegment {
+			return "@" + name
+		}
+		return "#" + name
+	case UserGroupSegment:
+		if s.Label != "" {
+			return s.Label
+		}
+		return "@" + s.ID
+	case SpecialSegment:
+		if s.Label != "" {
+			return s.Label
+		}
+		return "@" + s.ID
+	case LinkSegment:
+		if s.Label != "" {
+			return s.Label
+		}
+		return strings.TrimPrefix(s.URL, "mailto:")
+	case DateSegment:
+		return formatDate(s.Time, s.Format)
+	}
+	return s.Text
+}
+
+// dateLayouts maps the date tokens to Go layouts
+var dateLayouts = map[string]string{
+	DateNum:         "2006-01-02",
+	Date:            "January 2, 2006",
+	DateShort:       "Jan 2, 2006",
+	DateLong:        "Monday, January 2, 2006",
+	DatePretty:      "January 2, 2006",
+	DateShortPretty: "Jan 2, 2006",
+	DateLongPretty:  "Monday, January 2, 2006",
+	Time:            "3:04 PM",
+	TimeSecs:        "3:04:05 PM",
+}
+
+// formatDate formats the time using the date tokens in the format
+func formatDate(t time.Time, format string) string {
+	var b strings.Builder
+	for format != "" {
+		start := strings.IndexByte(format, '{')
+		end := strings.IndexByte(format, '}')
+		if start < 0 || end < start {
+			b.WriteString(format)
+			break
+		}
+		b.WriteString(format[:start])
+		token := format[start : end+1]
+		if layout, ok := dateLayouts[token]; ok {
+			b.WriteString(t.Format(layout))
+		} else {
+			b.WriteString(token)
+		}
+		format = format[end+1:]
+	}
+	return b.String()
+}
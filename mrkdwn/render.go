@@ -0,0 +1,230 @@
+package mrkdwn
+
+import (
+	"html"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Style markers which are converted when rendering
+const (
+	styleBold   = '*'
+	styleItalic = '_'
+	styleStrike = '~'
+	styleCode   = '`'
+	stylePre    = 'p' // ``` code block
+)
+
+// PlainText renders the segments as plain text without styles. Mentions are rendered as
+// @name and #name using the labels or the resolver which can be nil.
+func (segments Segments) PlainText(r Resolver) string {
+	var b strings.Builder
+	for i := range segments {
+		s := &segments[i]
+		switch s.Type {
+		case TextSegment:
+			b.WriteString(convertStyles(s.Text, identity, func(style byte, inner string) string {
+				return inner
+			}))
+		case LinkSegment:
+			b.WriteString(s.plainLink(r))
+		default:
+			b.WriteString(s.name(r))
+		}
+	}
+	return b.String()
+}
+
+// Markdown renders the segments as standard Markdown
+func (segments Segments) Markdown(r Resolver) string {
+	var b strings.Builder
+	for i := range segments {
+		s := &segments[i]
+		switch s.Type {
+		case TextSegment:
+			b.WriteString(convertStyles(s.Text, identity, markdownStyle))
+		case LinkSegment:
+			if !safeURL(s.URL) {
+				b.WriteString(s.plainLink(r))
+			} else if s.Label == "" {
+				b.WriteString("<" + markdownURL.Replace(s.URL) + ">")
+			} else {
+				b.WriteString("[" + markdownLabel.Replace(s.Label) + "](" + markdownURL.Replace(s.URL) + ")")
+			}
+		case DateSegment:
+			if s.URL != "" && safeURL(s.URL) {
+				b.WriteString("[" + markdownLabel.Replace(s.name(r)) + "](" + markdownURL.Replace(s.URL) + ")")
+			} else {
+				b.WriteString(s.name(r))
+			}
+		default:
+			b.WriteString(s.name(r))
+		}
+	}
+	return b.String()
+}
+
+// markdownLabel escapes the characters which would end a Markdown link label early
+var markdownLabel = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)
+
+// markdownURL percent-encodes the characters which would end a Markdown link destination early
+var markdownURL = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E")
+
+// HTML renders the segments as an HTML fragment. Text is escaped and line breaks are
+// rendered as <br>.
+func (segments Segments) HTML(r Resolver) string {
+	var b strings.Builder
+	for i := range segments {
+		s := &segments[i]
+		switch s.Type {
+		case TextSegment:
+			b.WriteString(convertStyles(s.Text, htmlText, htmlStyle))
+		case LinkSegment:
+			if !safeURL(s.URL) {
+				b.WriteString(html.EscapeString(s.plainLink(r)))
+				break
+			}
+			b.WriteString(`<a href="` + html.EscapeString(s.URL) + `">` + html.EscapeString(s.name(r)) + "</a>")
+		case DateSegment:
+			b.WriteString(`<time datetime="` + s.Time.Format(time.RFC3339) + `">` + html.EscapeString(s.name(r)) + "</time>")
+		default:
+			b.WriteString(html.EscapeString(s.name(r)))
+		}
+	}
+	return b.String()
+}
+
+// ToPlainText parses the message text and renders it as plain text
+func ToPlainText(text string, r Resolver) string {
+	return Parse(text).PlainText(r)
+}
+
+// ToMarkdown parses the message text and renders it as Markdown
+func ToMarkdown(text string, r Resolver) string {
+	return Parse(text).Markdown(r)
+}
+
+// ToHTML parses the message text and renders it as HTML
+func ToHTML(text string, r Resolver) string {
+	return Parse(text).HTML(r)
+}
+
+// plainLink renders a link as its name followed by the URL if they differ
+func (s *Segment) plainLink(r Resolver) string {
+	name := s.name(r)
+	if strings.TrimPrefix(s.URL, "mailto:") != name {
+		return name + " (" + s.URL + ")"
+	}
+	return name
+}
+
+// safeSchemes are the URL schemes rendered as links. Others like javascript: are rendered as text.
+var safeSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// safeURL returns true if the URL has a scheme which is safe to link to
+func safeURL(rawurl string) bool {
+	u, err := url.Parse(rawurl)
+	return err == nil && safeSchemes[strings.ToLower(u.Scheme)]
+}
+
+func identity(s string) string {
+	return s
+}
+
+// htmlText escapes literal text for HTML
+func htmlText(s string) string {
+	return strings.Replace(html.EscapeString(s), "\n", "<br>", -1)
+}
+
+// markdownStyle converts a Slack style to Markdown
+func markdownStyle(style byte, inner string) string {
+	switch style {
+	case styleBold:
+		return "**" + inner + "**"
+	case styleItalic:
+		return "_" + inner + "_"
+	case styleStrike:
+		return "~~" + inner + "~~"
+	case styleCode:
+		return "`" + inner + "`"
+	}
+	return "```\n" + strings.Trim(inner, "\n") + "\n```"
+}
+
+// htmlStyle converts a Slack style to HTML
+func htmlStyle(style byte, inner string) string {
+	switch style {
+	case styleBold:
+		return "<b>" + inner + "</b>"
+	case styleItalic:
+		return "<i>" + inner + "</i>"
+	case styleStrike:
+		return "<s>" + inner + "</s>"
+	case styleCode:
+		return "<code>" + html.EscapeString(inner) + "</code>"
+	}
+	return "<pre>" + html.EscapeString(strings.Trim(inner, "\n")) + "</pre>"
+}
+
+// convertStyles finds the styled parts of the text and converts them with wrap. Literal text
+// is passed through lit. Code is passed to wrap as is and other styles are converted recursively.
+func convertStyles(text string, lit func(string) string, wrap func(style byte, inner string) string) string {
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(text); i++ {
+		if strings.HasPrefix(text[i:], "```") {
+			if end := strings.Index(text[i+3:], "```"); end >= 0 {
+				b.WriteString(lit(text[last:i]))
+				b.WriteString(wrap(stylePre, text[i+3:i+3+end]))
+				i += end + 5
+				last = i + 1
+			}
+			continue
+		}
+		c := text[i]
+		if c != styleBold && c != styleItalic && c != styleStrike && c != styleCode {
+			continue
+		}
+		if i > 0 && isWord(text[i-1]) {
+			continue
+		}
+		end := closing(text, i)
+		if end < 0 {
+			continue
+		}
+		b.WriteString(lit(text[last:i]))
+		inner := text[i+1 : end]
+		if c == styleCode {
+			b.WriteString(wrap(c, inner))
+		} else {
+			b.WriteString(wrap(c, convertStyles(inner, lit, wrap)))
+		}
+		i = end
+		last = end + 1
+	}
+	b.WriteString(lit(text[last:]))
+	return b.String()
+}
+
+// closing returns the index of the marker closing the style opened at start or -1
+func closing(text string, start int) int {
+	c := text[start]
+	if start+1 >= len(text) || text[start+1] == ' ' || text[start+1] == c {
+		return -1
+	}
+	for j := start + 1; j < len(text); j++ {
+		switch {
+		case text[j] == '\n':
+			return -1
+		case text[j] == c && text[j-1] != ' ' && (j+1 == len(text) || !isWord(text[j+1])):
+			return j
+		}
+	}
+	return -1
+}
+
+// isWord returns true for ASCII letters and digits
+func isWord(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
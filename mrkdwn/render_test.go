@@ -0,0 +1,50 @@
+package mrkdwn
+
+import (
+	"testing"
+)
+
+func TestHTMLLinks(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"<https://example.com|site>", `<a href="https://example.com">site</a>`},
+		{"<HTTP://example.com>", `<a href="HTTP://example.com">HTTP://example.com</a>`},
+		{"<mailto:a@example.com|a@example.com>", `<a href="mailto:a@example.com">a@example.com</a>`},
+		{"<javascript:alert(1)|x>", "x (javascript:alert(1))"},
+		{"<JavaScript:alert(1)>", "JavaScript:alert(1)"},
+		{"<data:text/html,&lt;script&gt;|x>", "x (data:text/html,&lt;script&gt;)"},
+		{"<vbscript:msgbox|x>", "x (vbscript:msgbox)"},
+	}
+	for _, test := range tests {
+		if got := ToHTML(test.text, nil); got != test.want {
+			t.Errorf("ToHTML(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestMarkdownUnsafeLink(t *testing.T) {
+	if got, want := ToMarkdown("<javascript:alert(1)|x>", nil), "x (javascript:alert(1))"; got != want {
+		t.Errorf("ToMarkdown = %q, want %q", got, want)
+	}
+}
+
+func TestMarkdownLinks(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"<https://example.com|site>", "[site](https://example.com)"},
+		{"<https://example.com>", "<https://example.com>"},
+		{"<https://en.wikipedia.org/wiki/Go_(game)|see [docs]>", `[see \[docs\]](https://en.wikipedia.org/wiki/Go_%28game%29)`},
+		{"<https://example.com/a b|x](javascript:y)>", `[x\](javascript:y)](https://example.com/a%20b)`},
+		{`<https://example.com|a\b>`, `[a\\b](https://example.com)`},
+		{"<https://example.com/(x)>", "<https://example.com/%28x%29>"},
+	}
+	for _, test := range tests {
+		if got := ToMarkdown(test.text, nil); got != test.want {
+			t.Errorf("ToMarkdown(%q) = %q, want %q", test.text, got, test.want)
+		}
+	}
+}
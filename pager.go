@@ -0,0 +1,262 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+)
+
+// Pager pages through the results of a list or history method. It follows
+// response_metadata.next_cursor for cursor based methods and paging.pages for the
+// older paged methods. Stop calling Next to end early.
+//
+// Example:
+//
+//	it := s.HistoryIter("C123", "", "", false, 200)
+//	for it.Next() {
+//		for _, m := range it.Messages {
+//			...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Pager struct {
+	ctx    context.Context
+	cursor string // The cursor of the next page
+	page   int    // The number of the current page starting with 1
+	done   bool
+	err    error
+	fetch  func(ctx context.Context, cursor string, page int) (next string, pages int, err error)
+}
+
+// Next fetches the next page. It returns false when there are no more pages or on error.
+func (p *Pager) Next() bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	p.page++
+	next, pages, err := p.fetch(p.ctx, p.cursor, p.page)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	p.cursor = next
+	// Cursor based methods are done without a next cursor and paged ones on the last page
+	if next == "" && (pages == 0 || p.page >= pages) {
+		p.done = true
+	}
+	return true
+}
+
+// Page returns the number of the current page starting with 1
+func (p *Pager) Page() int {
+	return p.page
+}
+
+// Err returns the error which stopped the pager if any
+func (p *Pager) Err() error {
+	return p.err
+}
+
+// UserIterator pages through the users of the team
+type UserIterator struct {
+	Pager
+	Users []User // The users of the current page
+}
+
+// UserIter returns an iterator over the users with the given page size (0 for the Slack default)
+func (s *Slack) UserIter(pageSize int) *UserIterator {
+	return s.UserIterContext(context.Background(), pageSize)
+}
+
+// UserIterContext is UserIter with a custom context
+func (s *Slack) UserIterContext(ctx context.Context, pageSize int) *UserIterator {
+	it := &UserIterator{}
+	it.Pager = Pager{ctx: ctx, fetch: func(ctx context.Context, cursor string, page int) (string, int, error) {
+		params := url.Values{}
+		appendPage(cursor, pageSize, params)
+		r := &UserListResponse{}
+		if err := s.do(ctx, "users.list", params, r); err != nil {
+			return "", 0, err
+		}
+		it.Users = r.Members
+		return r.ResponseMetadata.NextCursor, 0, nil
+	}}
+	return it
+}
+
+// AllUsers returns all the users of the team
+func (s *Slack) AllUsers() ([]User, error) {
+	return s.AllUsersContext(context.Background())
+}
+
+// AllUsersContext is AllUsers with a custom context
+func (s *Slack) AllUsersContext(ctx context.Context) ([]User, error) {
+	var users []User
+	it := s.UserIterContext(ctx, 200)
+	for it.Next() {
+		users = append(users, it.Users...)
+	}
+	return users, it.Err()
+}
+
+// ConversationIterator pages through conversations
+type ConversationIterator struct {
+	Pager
+	Channels []Conversation // The conversations of the current page
+}
+
+// ConversationIter returns an iterator over the conversations of the given types. It replaces
+// ChannelList, GroupList and IMList for large teams.
+func (s *Slack) ConversationIter(types []string, excludeArchived bool, pageSize int) *ConversationIterator {
+	return s.ConversationIterContext(context.Background(), types, excludeArchived, pageSize)
+}
+
+// ConversationIterContext is ConversationIter with a custom context
+func (s *Slack) ConversationIterContext(ctx context.Context, types []string, excludeArchived bool, pageSize int) *ConversationIterator {
+	it := &ConversationIterator{}
+	it.Pager = Pager{ctx: ctx, fetch: func(ctx context.Context, cursor string, page int) (string, int, error) {
+		r, err := s.ConversationListContext(ctx, types, excludeArchived, cursor, pageSize)
+		if err != nil {
+			return "", 0, err
+		}
+		it.Channels = r.Channels
+		return r.ResponseMetadata.NextCursor, 0, nil
+	}}
+	return it
+}
+
+// AllConversations returns all the conversations of the given types
+func (s *Slack) AllConversations(types []string, excludeArchived bool) ([]Conversation, error) {
+	return s.AllConversationsContext(context.Background(), types, excludeArchived)
+}
+
+// AllConversationsContext is AllConversations with a custom context
+func (s *Slack) AllConversationsContext(ctx context.Context, types []string, excludeArchived bool) ([]Conversation, error) {
+	var channels []Conversation
+	it := s.ConversationIterContext(ctx, types, excludeArchived, 200)
+	for it.Next() {
+		channels = append(channels, it.Channels...)
+	}
+	return channels, it.Err()
+}
+
+// MessageIterator pages through the messages of a conversation or thread
+type MessageIterator struct {
+	Pager
+	Messages []Message // The messages of the current page
+}
+
+// HistoryIter returns an iterator over the messages of the conversation between oldest and latest,
+// newest first
+func (s *Slack) HistoryIter(channel string, latest, oldest Timestamp, inclusive bool, pageSize int) *MessageIterator {
+	return s.HistoryIterContext(context.Background(), channel, latest, oldest, inclusive, pageSize)
+}
+
+// HistoryIterContext is HistoryIter with a custom context
+func (s *Slack) HistoryIterContext(ctx context.Context, channel string, latest, oldest Timestamp, inclusive bool, pageSize int) *MessageIterator {
+	it := &MessageIterator{}
+	it.Pager = Pager{ctx: ctx, fetch: func(ctx context.Context, cursor string, page int) (string, int, error) {
+		r, err := s.ConversationHistoryContext(ctx, channel, latest, oldest, inclusive, cursor, pageSize)
+		if err != nil {
+			return "", 0, err
+		}
+		it.Messages = r.Messages
+		return r.ResponseMetadata.NextCursor, 0, nil
+	}}
+	return it
+}
+
+// RepliesIter returns an iterator over the thread of the message with the given timestamp
+func (s *Slack) RepliesIter(channel string, ts Timestamp, pageSize int) *MessageIterator {
+	return s.RepliesIterContext(context.Background(), channel, ts, pageSize)
+}
+
+// RepliesIterContext is RepliesIter with a custom context
+func (s *Slack) RepliesIterContext(ctx context.Context, channel string, ts Timestamp, pageSize int) *MessageIterator {
+	it := &MessageIterator{}
+	it.Pager = Pager{ctx: ctx, fetch: func(ctx context.Context, cursor string, page int) (string, int, error) {
+		r, err := s.ConversationRepliesContext(ctx, channel, ts, cursor, pageSize)
+		if err != nil {
+			return "", 0, err
+		}
+		it.Messages = r.Messages
+		return r.ResponseMetadata.NextCursor, 0, nil
+	}}
+	return it
+}
+
+// MemberIterator pages through the members of a conversation
+type MemberIterator struct {
+	Pager
+	Members []string // The user IDs of the current page
+}
+
+// MembersIter returns an iterator over the members of the conversation
+func (s *Slack) MembersIter(channel string, pageSize int) *MemberIterator {
+	return s.MembersIterContext(context.Background(), channel, pageSize)
+}
+
+// MembersIterContext is MembersIter with a custom context
+func (s *Slack) MembersIterContext(ctx context.Context, channel string, pageSize int) *MemberIterator {
+	it := &MemberIterator{}
+	it.Pager = Pager{ctx: ctx, fetch: func(ctx context.Context, cursor string, page int) (string, int, error) {
+		r, err := s.ConversationMembersContext(ctx, channel, cursor, pageSize)
+		if err != nil {
+			return "", 0, err
+		}
+		it.Members = r.Members
+		return r.ResponseMetadata.NextCursor, 0, nil
+	}}
+	return it
+}
+
+// FileIterator pages through files
+type FileIterator struct {
+	Pager
+	Files []File // The files of the current page
+}
+
+// FileIter returns an iterator over the files matching the filters of FileList
+func (s *Slack) FileIter(user, tsFrom, tsTo string, types []string, pageSize int) *FileIterator {
+	return s.FileIterContext(context.Background(), user, tsFrom, tsTo, types, pageSize)
+}
+
+// FileIterContext is FileIter with a custom context
+func (s *Slack) FileIterContext(ctx context.Context, user, tsFrom, tsTo string, types []string, pageSize int) *FileIterator {
+	it := &FileIterator{}
+	it.Pager = Pager{ctx: ctx, fetch: func(ctx context.Context, cursor string, page int) (string, int, error) {
+		r, err := s.FileListContext(ctx, user, tsFrom, tsTo, types, pageSize, page)
+		if err != nil {
+			return "", 0, err
+		}
+		it.Files = r.Files
+		return "", r.Paging.Pages, nil
+	}}
+	return it
+}
+
+// ReactionIterator pages through the items a user reacted to
+type ReactionIterator struct {
+	Pager
+	Items []ReactionsGetResponse // The items of the current page
+}
+
+// ReactionsIter returns an iterator over the items the user (or the current user if empty) reacted to
+func (s *Slack) ReactionsIter(user string, full bool, pageSize int) *ReactionIterator {
+	return s.ReactionsIterContext(context.Background(), user, full, pageSize)
+}
+
+// ReactionsIterContext is ReactionsIter with a custom context
+func (s *Slack) ReactionsIterContext(ctx context.Context, user string, full bool, pageSize int) *ReactionIterator {
+	it := &ReactionIterator{}
+	it.Pager = Pager{ctx: ctx, fetch: func(ctx context.Context, cursor string, page int) (string, int, error) {
+		r, err := s.ReactionsListContext(ctx, user, full, pageSize, page)
+		if err != nil {
+			return "", 0, err
+		}
+		it.Items = r.Items
+		return "", r.Paging.Pages, nil
+	}}
+	return it
+}
@@ -0,0 +1,104 @@
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// pagerServer answers every request with the reply for the request and counts the calls
+func pagerServer(t *testing.T, reply func(r *http.Request) string) (*Slack, *int) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(reply(r)))
+	}))
+	t.Cleanup(ts.Close)
+	s, err := New(SetToken("xoxb-test"), SetURL(ts.URL+"/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, &calls
+}
+
+func TestPagerCursor(t *testing.T) {
+	s, calls := pagerServer(t, func(r *http.Request) string {
+		if r.FormValue("cursor") == "" {
+			return `{"ok":true,"members":[{"id":"U1"}],"response_metadata":{"next_cursor":"c2"}}`
+		}
+		return `{"ok":true,"members":[{"id":"U2"}],"response_metadata":{"next_cursor":""}}`
+	})
+	users, err := s.AllUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[0].ID != "U1" || users[1].ID != "U2" {
+		t.Errorf("users = %+v, want U1 and U2", users)
+	}
+	if *calls != 2 {
+		t.Errorf("calls = %d, want 2", *calls)
+	}
+}
+
+func TestPagerPaged(t *testing.T) {
+	var pages []string
+	s, calls := pagerServer(t, func(r *http.Request) string {
+		pages = append(pages, r.FormValue("page"))
+		return `{"ok":true,"files":[{"id":"F1"}],"paging":{"pages":3}}`
+	})
+	it := s.FileIter("", "", "", nil, 1)
+	n := 0
+	for it.Next() {
+		n++
+		if it.Page() != n {
+			t.Errorf("page = %d, want %d", it.Page(), n)
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || *calls != 3 {
+		t.Errorf("pages = %d, calls = %d, want 3", n, *calls)
+	}
+	// The first page is requested without the page parameter
+	if len(pages) != 3 || pages[0] != "" || pages[1] != "2" || pages[2] != "3" {
+		t.Errorf("page params = %q, want [\"\" 2 3]", pages)
+	}
+}
+
+func TestPagerPagedEmpty(t *testing.T) {
+	s, calls := pagerServer(t, func(r *http.Request) string {
+		return `{"ok":true,"files":[],"paging":{"pages":0}}`
+	})
+	it := s.FileIter("", "", "", nil, 0)
+	for it.Next() {
+	}
+	if err := it.Err(); err != nil || *calls != 1 {
+		t.Errorf("err = %v, calls = %d, want no error and 1 call", err, *calls)
+	}
+}
+
+func TestPagerError(t *testing.T) {
+	s, calls := pagerServer(t, func(r *http.Request) string {
+		if r.FormValue("cursor") == "" {
+			return `{"ok":true,"members":[{"id":"U1"}],"response_metadata":{"next_cursor":"c2"}}`
+		}
+		return `{"ok":false,"error":"invalid_cursor"}`
+	})
+	it := s.UserIter(0)
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if n != 1 {
+		t.Errorf("pages = %d, want 1", n)
+	}
+	if err := it.Err(); err == nil || err.Error() != "invalid_cursor" {
+		t.Errorf("err = %v, want invalid_cursor", err)
+	}
+	// Once failed the pager stays done
+	if it.Next() || *calls != 2 {
+		t.Errorf("Next after error fetched again, calls = %d", *calls)
+	}
+}
@@ -0,0 +1,276 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrSenderClosed is returned when sending on a closed sender
+	ErrSenderClosed = errors.New("sender is closed")
+	// ErrQueueFull is returned when the queue of the channel is full
+	ErrQueueFull = errors.New("channel queue is full")
+)
+
+// Delivery is the result of posting a queued message
+type Delivery struct {
+	Request  *PostMessageRequest
+	Reply    *PostMessageReply // The reply including the timestamp of the posted message if successful
+	Err      error
+	Attempts int
+}
+
+// outbound is a message waiting in the queue
+type outbound struct {
+	m      *PostMessageRequest
+	escape bool
+	result chan *Delivery
+}
+
+// channelQueue holds the pending messages of a single channel
+type channelQueue struct {
+	items []*outbound
+}
+
+// Sender posts messages asynchronously. Messages are queued per channel and posted in
+// order by a goroutine per channel which is started when the first message is queued
+// and exits when the queue is empty. Messages to the same channel are spaced by the
+// configured interval and transient failures are retried.
+//
+// Example:
+//
+//	sender := slack.NewSender(s, slack.SenderOnDelivery(func(d *slack.Delivery) { ... }))
+//	defer sender.Close(context.Background())
+//	result, err := sender.Send(&slack.PostMessageRequest{Channel: "C123", Text: "alert"}, true)
+type Sender struct {
+	s          *Slack
+	mutex      sync.Mutex
+	queues     map[string]*channelQueue
+	last       map[string]time.Time // When the last message was posted to each channel
+	closed     bool
+	closeErr   error // The error of the Close context which fails the remaining messages
+	wg         sync.WaitGroup
+	ctx        context.Context
+	cancel     context.CancelFunc
+	interval   time.Duration   // Minimum time between messages to the same channel
+	queueSize  int             // Maximum pending messages per channel, 0 for no limit
+	maxRetries int             // How many times to retry transient failures
+	backoff    time.Duration   // Initial wait before retrying, doubled on every retry
+	onDelivery func(*Delivery) // Optional callback for every delivery
+}
+
+// SenderOption configures a Sender
+type SenderOption func(*Sender)
+
+// SenderInterval sets the minimum time between messages to the same channel. The default is one second.
+func SenderInterval(interval time.Duration) SenderOption {
+	return func(q *Sender) {
+		q.interval = interval
+	}
+}
+
+// SenderQueueSize limits the number of pending messages per channel. Send fails with
+// ErrQueueFull once reached. The default of 0 does not limit the queue.
+func SenderQueueSize(size int) SenderOption {
+	return func(q *Sender) {
+		q.queueSize = size
+	}
+}
+
+// SenderRetries sets how many times transient failures are retried and the initial backoff
+// which is doubled on every retry. The default is 3 retries starting with one second.
+func SenderRetries(maxRetries int, backoff time.Duration) SenderOption {
+	return func(q *Sender) {
+		q.maxRetries, q.backoff = maxRetries, backoff
+	}
+}
+
+// SenderOnDelivery sets a callback for the result of every message. The callback is called
+// from the goroutine of the channel so results of a channel are reported in order.
+func SenderOnDelivery(fn func(*Delivery)) SenderOption {
+	return func(q *Sender) {
+		q.onDelivery = fn
+	}
+}
+
+// NewSender creates an asynchronous sender posting with the given client
+func NewSender(s *Slack, options ...SenderOption) *Sender {
+	q := &Sender{
+		s:          s,
+		queues:     make(map[string]*channelQueue),
+		last:       make(map[string]time.Time),
+		interval:   time.Second,
+		maxRetries: 3,
+		backoff:    time.Second,
+	}
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	for _, opt := range options {
+		opt(q)
+	}
+	return q
+}
+
+// Send queues the message and returns a channel receiving its delivery result. The result
+// channel is buffered so it is fine to ignore it.
+func (q *Sender) Send(m *PostMessageRequest, escape bool) (<-chan *Delivery, error) {
+	o := &outbound{m: m, escape: escape, result: make(chan *Delivery, 1)}
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.closed {
+		return nil, ErrSenderClosed
+	}
+	cq, ok := q.queues[m.Channel]
+	if !ok {
+		cq = &channelQueue{}
+		q.queues[m.Channel] = cq
+		q.wg.Add(1)
+		go q.run(m.Channel, cq)
+	}
+	if q.queueSize > 0 && len(cq.items) >= q.queueSize {
+		return nil, ErrQueueFull
+	}
+	cq.items = append(cq.items, o)
+	return o.result, nil
+}
+
+// Pending returns the number of messages waiting to be posted to the channel
+func (q *Sender) Pending(channel string) int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if cq, ok := q.queues[channel]; ok {
+		return len(cq.items)
+	}
+	return 0
+}
+
+// Close stops accepting messages and waits for the queued messages to be posted. If the
+// context is done first, the remaining messages are failed with the context error.
+func (q *Sender) Close(ctx context.Context) error {
+	q.mutex.Lock()
+	q.closed = true
+	q.mutex.Unlock()
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		q.cancel()
+		return nil
+	case <-ctx.Done():
+		q.mutex.Lock()
+		q.closeErr = ctx.Err()
+		q.mutex.Unlock()
+		q.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// run posts the messages of the channel until its queue is empty
+func (q *Sender) run(channel string, cq *channelQueue) {
+	defer q.wg.Done()
+	for {
+		q.mutex.Lock()
+		if len(cq.items) == 0 {
+			delete(q.queues, channel)
+			q.pruneLast()
+			q.mutex.Unlock()
+			return
+		}
+		o := cq.items[0]
+		cq.items[0] = nil
+		cq.items = cq.items[1:]
+		q.mutex.Unlock()
+		d := q.deliver(o)
+		if d.Err != nil && q.ctx.Err() != nil {
+			// Stopped by Close so report its context error rather than how the post was cut short
+			q.mutex.Lock()
+			d.Err = q.closeErr
+			q.mutex.Unlock()
+		}
+		o.result <- d
+		if q.onDelivery != nil {
+			q.onDelivery(d)
+		}
+	}
+}
+
+// pruneLast forgets the last post time of idle channels once the interval has passed.
+// It must be called with the mutex held.
+func (q *Sender) pruneLast() {
+	now := time.Now()
+	for channel, last := range q.last {
+		if _, active := q.queues[channel]; !active && now.Sub(last) >= q.interval {
+			delete(q.last, channel)
+		}
+	}
+}
+
+// deliver posts a single message with retries
+func (q *Sender) deliver(o *outbound) *Delivery {
+	d := &Delivery{Request: o.m}
+	backoff := q.backoff
+	for {
+		// The last post time outlives the queue so a channel is spaced even when its queue drains
+		q.mutex.Lock()
+		last := q.last[o.m.Channel]
+		q.mutex.Unlock()
+		if wait := last.Add(q.interval).Sub(time.Now()); wait > 0 {
+			if d.Err = sleepContext(q.ctx, wait); d.Err != nil {
+				return d
+			}
+		}
+		d.Attempts++
+		d.Reply, d.Err = q.s.PostMessageContext(q.ctx, o.m, o.escape)
+		q.mutex.Lock()
+		q.last[o.m.Channel] = time.Now()
+		q.mutex.Unlock()
+		if d.Err == nil || d.Attempts > q.maxRetries || !isTransient(d.Err) {
+			return d
+		}
+		wait := backoff
+		if rl, ok := d.Err.(*RateLimitedError); ok && rl.RetryAfter > wait {
+			wait = rl.RetryAfter
+		}
+		q.s.errorf("Retrying message to %s in %v - %v\n", o.m.Channel, wait, d.Err)
+		if err := sleepContext(q.ctx, wait); err != nil {
+			return d
+		}
+		backoff *= 2
+	}
+}
+
+// isTransient returns true if the error is worth retrying
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// The request context is done so a retry would fail the same way
+		return false
+	}
+	switch err := err.(type) {
+	case *RateLimitedError:
+		return true
+	case *Error:
+		// Only server errors are worth retrying, client errors fail the same way again
+		return err.ID == "http_error" && err.StatusCode >= 500 || transientErrors[err.ID]
+	case Response:
+		// Slack API failures are returned as the response itself
+		return transientErrors[err.Error()]
+	case net.Error:
+		// Only timeouts are worth retrying, errors like a refused connection or a bad host are not
+		return err.Timeout()
+	}
+	return false
+}
+
+// transientErrors are the Slack errors worth retrying
+var transientErrors = map[string]bool{
+	"internal_error":      true,
+	"fatal_error":         true,
+	"service_unavailable": true,
+	"request_timeout":     true,
+}
@@ -0,0 +1,231 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultRetryAfter is used when Slack does not tell us how long to wait
+const defaultRetryAfter = time.Second
+
+// RateLimitedError is returned when Slack rate limited the request and there are no more retries left
+type RateLimitedError struct {
+	RetryAfter time.Duration // How long Slack asked us to wait before retrying
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("ratelimited: Slack asked to retry after %v", e.RetryAfter)
+}
+
+// newRateLimitedError creates the error from the Retry-After header of the response
+func newRateLimitedError(resp *http.Response) *RateLimitedError {
+	retryAfter := defaultRetryAfter
+	if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && sec >= 0 {
+		retryAfter = time.Duration(sec) * time.Second
+	}
+	return &RateLimitedError{RetryAfter: retryAfter}
+}
+
+// RetryPolicy controls how requests that were rate limited are retried.
+// The zero value does not retry at all.
+type RetryPolicy struct {
+	MaxRetries int           // Maximum number of retries for a single request
+	MaxWait    time.Duration // Give up if Slack asks us to wait longer than this - 0 means no limit
+}
+
+// shouldRetry checks if we can retry after the given attempt and wait time
+func (p RetryPolicy) shouldRetry(attempt int, wait time.Duration) bool {
+	if attempt >= p.MaxRetries {
+		return false
+	}
+	return p.MaxWait == 0 || wait <= p.MaxWait
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Tier is the Slack rate limit tier of an API method - see https://api.slack.com/docs/rate-limits
+type Tier int
+
+const (
+	// TierDefault is used for methods without an explicit tier and maps to Tier3
+	TierDefault Tier = iota
+	// Tier1 allows about 1 request per minute
+	Tier1
+	// Tier2 allows about 20 requests per minute
+	Tier2
+	// Tier3 allows about 50 requests per minute
+	Tier3
+	// Tier4 allows about 100 requests per minute
+	Tier4
+	// TierPostMessage allows about 1 message per second per channel
+	TierPostMessage
+)
+
+// tierLimit is the steady rate (per second) and burst allowed for a tier
+type tierLimit struct {
+	rate  float64
+	burst float64
+}
+
+var tierLimits = map[Tier]tierLimit{
+	Tier1:           {1.0 / 60, 1},
+	Tier2:           {20.0 / 60, 3},
+	Tier3:           {50.0 / 60, 5},
+	Tier4:           {100.0 / 60, 10},
+	TierPostMessage: {1, 1},
+}
+
+// methodTiers holds the known tiers of the methods implemented by the library
+var methodTiers = map[string]Tier{
+	"apps.connections.open":       Tier1,
+	"rtm.start":                   Tier1,
+	"users.admin.invite":          Tier1,
+	"channels.create":             Tier2,
+	"channels.list":               Tier2,
+	"conversations.archive":       Tier2,
+	"conversations.close":         Tier2,
+	"conversations.create":        Tier2,
+	"conversations.list":          Tier2,
+	"conversations.rename":        Tier2,
+	"conversations.setPurpose":    Tier2,
+	"conversations.setTopic":      Tier2,
+	"conversations.unarchive":     Tier2,
+	"emoji.list":                  Tier2,
+	"files.list":                  Tier2,
+	"files.upload":                Tier2,
+	"groups.create":               Tier2,
+	"groups.list":                 Tier2,
+	"im.list":                     Tier2,
+	"mpim.list":                   Tier2,
+	"reactions.list":              Tier2,
+	"users.list":                  Tier2,
+	"chat.delete":                 Tier3,
+	"chat.deleteScheduledMessage": Tier3,
+	"chat.scheduleMessage":        Tier3,
+	"chat.scheduledMessages.list": Tier3,
+	"chat.update":                 Tier3,
+	"auth.test":                   Tier4,
+	"chat.postEphemeral":          Tier4,
+	"conversations.members":       Tier4,
+	"files.info":                  Tier4,
+	"users.info":                  Tier4,
+	"chat.postMessage":            TierPostMessage,
+}
+
+// bucket is a token bucket refilled at a constant rate
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  tierLimit
+}
+
+// reserve takes a token from the bucket and returns how long to wait before using it
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.rate
+	if b.tokens > b.limit.burst {
+		b.tokens = b.limit.burst
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.limit.rate * float64(time.Second))
+}
+
+// idle returns true if the bucket has refilled so dropping it is the same as keeping it
+func (b *bucket) idle(now time.Time) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*b.limit.rate >= b.limit.burst
+}
+
+// bucketSweepInterval is how often idle buckets are evicted
+const bucketSweepInterval = time.Minute
+
+// RateLimiter is a client side token bucket limiter keyed by API method.
+// chat.postMessage is limited per channel.
+type RateLimiter struct {
+	mutex     sync.Mutex
+	tiers     map[string]Tier
+	buckets   map[string]*bucket
+	lastSweep time.Time // When idle buckets were last evicted
+}
+
+// NewRateLimiter creates a limiter using the known Slack tiers of the API methods
+func NewRateLimiter() *RateLimiter {
+	l := &RateLimiter{tiers: make(map[string]Tier), buckets: make(map[string]*bucket)}
+	for method, tier := range methodTiers {
+		l.tiers[method] = tier
+	}
+	return l
+}
+
+// SetTier overrides the tier of the given method
+func (l *RateLimiter) SetTier(method string, tier Tier) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.tiers[method] = tier
+	// Drop existing buckets so the new tier is applied
+	for key := range l.buckets {
+		if key == method || strings.HasPrefix(key, method+":") {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Wait blocks until the method can be called for the given channel or the context is done
+func (l *RateLimiter) Wait(ctx context.Context, method, channel string) error {
+	l.mutex.Lock()
+	tier := l.tiers[method]
+	limit, ok := tierLimits[tier]
+	if !ok {
+		// TierDefault and tiers we do not know fall back to Tier3
+		tier, limit = Tier3, tierLimits[Tier3]
+	}
+	key := method
+	if tier == TierPostMessage {
+		key += ":" + channel
+	}
+	now := time.Now()
+	if now.Sub(l.lastSweep) > bucketSweepInterval {
+		// Evict the buckets of idle methods and channels so they do not pile up
+		for k, b := range l.buckets {
+			if b.idle(now) {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: limit.burst, last: now, limit: limit}
+		l.buckets[key] = b
+	}
+	wait := b.reserve(now)
+	l.mutex.Unlock()
+	if wait == 0 {
+		return nil
+	}
+	if err := sleepContext(ctx, wait); err != nil {
+		// Give back the token we did not use
+		l.mutex.Lock()
+		b.tokens++
+		l.mutex.Unlock()
+		return err
+	}
+	return nil
+}
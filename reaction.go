@@ -1,8 +1,10 @@
 package slack
 
 import (
+	"context"
 	"errors"
 	"net/url"
+	"strconv"
 )
 
 // Reaction contains the reaction details
@@ -25,11 +27,11 @@ type ReactionsGetResponse struct {
 // ReactionsListResponse is the response to the ReactionsList request
 type ReactionsListResponse struct {
 	slackResponse
-	Items  ReactionsGetResponse `json:"items"`
-	Paging paging               `json:"paging"`
+	Items  []ReactionsGetResponse `json:"items"` // Every item of the page - this used to be a single item
+	Paging paging                 `json:"paging"`
 }
 
-func (s *Slack) reactionsAction(name, file, fileComment, channel, timestamp, action string) (Response, error) {
+func (s *Slack) reactionsAction(ctx context.Context, name, file, fileComment, channel string, timestamp Timestamp, action string) (Response, error) {
 	if name == "" {
 		return nil, errors.New("Please provide the emoji name")
 	}
@@ -40,9 +42,9 @@ func (s *Slack) reactionsAction(name, file, fileComment, channel, timestamp, act
 	appendNotEmpty("file", file, params)
 	appendNotEmpty("file_comment", fileComment, params)
 	appendNotEmpty("channel", channel, params)
-	appendNotEmpty("timestamp", timestamp, params)
+	appendNotEmpty("timestamp", string(timestamp), params)
 	r := &slackResponse{}
-	err := s.do(action, params, r)
+	err := s.do(ctx, action, params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -50,27 +52,42 @@ func (s *Slack) reactionsAction(name, file, fileComment, channel, timestamp, act
 }
 
 // ReactionsAdd to either file, fileComment or a combination of channel and timestamp
-func (s *Slack) ReactionsAdd(name, file, fileComment, channel, timestamp string) (Response, error) {
-	return s.reactionsAction(name, file, fileComment, channel, timestamp, "reactions.add")
+func (s *Slack) ReactionsAdd(name, file, fileComment, channel string, timestamp Timestamp) (Response, error) {
+	return s.ReactionsAddContext(context.Background(), name, file, fileComment, channel, timestamp)
+}
+
+// ReactionsAddContext is ReactionsAdd with a custom context
+func (s *Slack) ReactionsAddContext(ctx context.Context, name, file, fileComment, channel string, timestamp Timestamp) (Response, error) {
+	return s.reactionsAction(ctx, name, file, fileComment, channel, timestamp, "reactions.add")
 }
 
 // ReactionsRemove from either file, fileComment or a combination of channel and timestamp
-func (s *Slack) ReactionsRemove(name, file, fileComment, channel, timestamp string) (Response, error) {
-	return s.reactionsAction(name, file, fileComment, channel, timestamp, "reactions.remove")
+func (s *Slack) ReactionsRemove(name, file, fileComment, channel string, timestamp Timestamp) (Response, error) {
+	return s.ReactionsRemoveContext(context.Background(), name, file, fileComment, channel, timestamp)
+}
+
+// ReactionsRemoveContext is ReactionsRemove with a custom context
+func (s *Slack) ReactionsRemoveContext(ctx context.Context, name, file, fileComment, channel string, timestamp Timestamp) (Response, error) {
+	return s.reactionsAction(ctx, name, file, fileComment, channel, timestamp, "reactions.remove")
 }
 
 // ReactionsGet for either file, fileComment or a combination of channel and timestamp
-func (s *Slack) ReactionsGet(file, fileComment, channel, timestamp string, full bool) (*ReactionsGetResponse, error) {
+func (s *Slack) ReactionsGet(file, fileComment, channel string, timestamp Timestamp, full bool) (*ReactionsGetResponse, error) {
+	return s.ReactionsGetContext(context.Background(), file, fileComment, channel, timestamp, full)
+}
+
+// ReactionsGetContext is ReactionsGet with a custom context
+func (s *Slack) ReactionsGetContext(ctx context.Context, file, fileComment, channel string, timestamp Timestamp, full bool) (*ReactionsGetResponse, error) {
 	params := url.Values{}
 	appendNotEmpty("file", file, params)
 	appendNotEmpty("file_comment", fileComment, params)
 	appendNotEmpty("channel", channel, params)
-	appendNotEmpty("timestamp", timestamp, params)
+	appendNotEmpty("timestamp", string(timestamp), params)
 	if full {
 		params.Set("full", "true")
 	}
 	r := &ReactionsGetResponse{}
-	err := s.do("reactions.get", params, r)
+	err := s.do(ctx, "reactions.get", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -79,13 +96,24 @@ func (s *Slack) ReactionsGet(file, fileComment, channel, timestamp string, full
 
 // ReactionsList filtered by user (optional and defaults to current)
 func (s *Slack) ReactionsList(user string, full bool, count, page int) (*ReactionsListResponse, error) {
+	return s.ReactionsListContext(context.Background(), user, full, count, page)
+}
+
+// ReactionsListContext is ReactionsList with a custom context
+func (s *Slack) ReactionsListContext(ctx context.Context, user string, full bool, count, page int) (*ReactionsListResponse, error) {
 	params := url.Values{}
 	appendNotEmpty("user", user, params)
 	if full {
 		params.Set("full", "true")
 	}
+	if page > 1 {
+		appendNotEmpty("page", strconv.Itoa(page), params)
+	}
+	if count > 0 {
+		appendNotEmpty("count", strconv.Itoa(count), params)
+	}
 	r := &ReactionsListResponse{}
-	err := s.do("reactions.list", params, r)
+	err := s.do(ctx, "reactions.list", params, r)
 	if err != nil {
 		return nil, err
 	}
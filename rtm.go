@@ -1,11 +1,17 @@
 package slack
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
+	"sync"
+	"time"
 
 	gerr "github.com/go-errors/errors"
 	"github.com/gorilla/websocket"
@@ -13,9 +19,11 @@ import (
 
 // WSMessageResponse holds a response to a WS request
 type WSMessageResponse struct {
-	OK      bool `json:"ok"`
-	ReplyTo int  `json:"reply_to"`
-	Error   struct {
+	OK        bool      `json:"ok"`
+	ReplyTo   int       `json:"reply_to"`
+	Timestamp Timestamp `json:"ts,omitempty"`
+	Text      string    `json:"text,omitempty"`
+	Error     struct {
 		Code int    `json:"code"`
 		Msg  string `json:"msg"`
 	} `json:"error"`
@@ -33,7 +41,7 @@ type RTMStartReply struct {
 		ManualPresence string                 `json:"manual_presence"`
 	} `json:"self"`
 	Team          Team      `json:"team"`
-	LatestEventTS string    `json:"latest_event_ts"`
+	LatestEventTS Timestamp `json:"latest_event_ts"`
 	Channels      []Channel `json:"channels"`
 	Groups        []Group   `json:"groups"`
 	IMS           []IM      `json:"ims"`
@@ -41,90 +49,415 @@ type RTMStartReply struct {
 	Bots          []Bot     `json:"bots"`
 }
 
+// RTM lifecycle events sent on the in channel when the RTM connection is managed - see SetRTMReconnect
+const (
+	RTMConnecting   = "connecting"   // Sent before trying to reconnect
+	RTMConnected    = "connected"    // Sent when the connection is established
+	RTMDisconnected = "disconnected" // Sent when the connection drops, with the error details
+)
+
+// rtmConfig holds the options of the RTM connection
+type rtmConfig struct {
+	reconnect  bool          // Should we manage the connection and reconnect when it drops
+	minBackoff time.Duration // Initial wait before reconnecting
+	maxBackoff time.Duration // Maximum wait between reconnect attempts
+	ping       time.Duration // Interval between keepalive pings - 0 disables them
+	timeout    time.Duration // Connection is dead if nothing is received for this long
+	events     chan Event    // Optional channel for the typed events
+	ackTimeout time.Duration // How long to wait for acknowledgements of sent messages
+}
+
+// ErrRTMTimeout is returned when the RTM connection is deemed dead by the keepalive - see SetRTMPing
+var ErrRTMTimeout = &Error{ID: "rtm_timeout", Detail: "No messages or pongs received from the RTM websocket in time"}
+
+// SetRTMPing turns on the RTM keepalive. A Slack ping message and a websocket ping are sent
+// every interval. If nothing (including the matching pongs) is received for timeout, the
+// connection is deemed dead and closed with ErrRTMTimeout which is reported as the error /
+// disconnected event on the in channel.
+func SetRTMPing(interval, timeout time.Duration) OptionFunc {
+	return func(s *Slack) error {
+		if interval <= 0 || timeout <= interval {
+			err := newError("bad_ping", "Invalid RTM ping interval %v and timeout %v", interval, timeout)
+			s.errorf("%v\n", err)
+			return err
+		}
+		s.rtm.ping = interval
+		s.rtm.timeout = timeout
+		return nil
+	}
+}
+
+// RTMPing is the keepalive message sent to Slack
+type RTMPing struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+}
+
+// keepalive tracks the pings sent on a connection and the matching pongs
+type keepalive struct {
+	mutex   sync.Mutex
+	pending map[int]time.Time // Ping ID to the time it was sent
+	dead    bool              // Was the connection deemed dead
+}
+
+// pong clears the matching ping and returns its round trip time
+func (k *keepalive) pong(id int) (time.Duration, bool) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	sent, ok := k.pending[id]
+	if !ok {
+		return 0, false
+	}
+	delete(k.pending, id)
+	return time.Since(sent), true
+}
+
+// expired checks if any of the pings is not answered for longer than timeout and marks the connection dead
+func (k *keepalive) expired(timeout time.Duration) bool {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	for _, sent := range k.pending {
+		if time.Since(sent) > timeout {
+			k.dead = true
+		}
+	}
+	return k.dead
+}
+
+// isDead returns true if the connection was deemed dead
+func (k *keepalive) isDead() bool {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	return k.dead
+}
+
+// rtmKeepalive sends pings on the connection until done is closed
+func (s *Slack) rtmKeepalive(ws *websocket.Conn, k *keepalive, done chan struct{}) {
+	ticker := time.NewTicker(s.rtm.ping)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		if k.expired(s.rtm.timeout) {
+			s.errorf("%s\n", ErrRTMTimeout.Error())
+			ws.Close()
+			return
+		}
+		s.mutex.Lock()
+		s.mid++
+		id := s.mid
+		// Register the ping before writing so a fast pong is not ignored
+		k.mutex.Lock()
+		k.pending[id] = time.Now()
+		k.mutex.Unlock()
+		deadline := time.Now().Add(s.rtm.ping)
+		err := ws.SetWriteDeadline(deadline)
+		if err == nil {
+			err = ws.WriteJSON(&RTMPing{ID: id, Type: "ping"})
+		}
+		if err == nil {
+			err = ws.WriteControl(websocket.PingMessage, nil, deadline)
+		}
+		ws.SetWriteDeadline(time.Time{})
+		s.mutex.Unlock()
+		if err != nil {
+			s.errorf("Error sending RTM ping - %v\n", err)
+			k.mutex.Lock()
+			delete(k.pending, id)
+			k.mutex.Unlock()
+		}
+	}
+}
+
+// SetRTMReconnect turns on the managed RTM mode. When the websocket drops, RTMStart
+// reconnects with jittered exponential backoff between minBackoff and maxBackoff,
+// reusing the same in channel. The connecting, connected and disconnected lifecycle
+// events are sent on the in channel and RTMInfo returns the refreshed snapshot.
+func SetRTMReconnect(minBackoff, maxBackoff time.Duration) OptionFunc {
+	return func(s *Slack) error {
+		if minBackoff <= 0 {
+			minBackoff = time.Second
+		}
+		if maxBackoff < minBackoff {
+			maxBackoff = time.Minute
+			if maxBackoff < minBackoff {
+				maxBackoff = minBackoff
+			}
+		}
+		s.rtm.reconnect = true
+		s.rtm.minBackoff = minBackoff
+		s.rtm.maxBackoff = maxBackoff
+		return nil
+	}
+}
+
+// SetRTMEvents sets a channel on which the RTM events are delivered as typed events
+// (see DecodeEvent) in addition to the in channel passed to RTMStart. The in channel
+// can be nil if only the typed events are needed.
+func SetRTMEvents(events chan Event) OptionFunc {
+	return func(s *Slack) error {
+		s.rtm.events = events
+		return nil
+	}
+}
+
 // RTMStart starts the websocket
-func (s *Slack) RTMStart(origin string, in chan *Message, context interface{}) (*RTMStartReply, error) {
-	r := &RTMStartReply{}
-	err := s.do("rtm.start", url.Values{}, r)
+func (s *Slack) RTMStart(origin string, in chan *Message, userContext interface{}) (*RTMStartReply, error) {
+	return s.RTMStartContext(context.Background(), origin, in, userContext)
+}
+
+// RTMStartContext is RTMStart with a custom context used for rtm.start and the websocket dial.
+// The websocket itself lives until RTMStop is called or the connection drops. A connection
+// started earlier is stopped first so its goroutines do not leak.
+func (s *Slack) RTMStartContext(ctx context.Context, origin string, in chan *Message, userContext interface{}) (*RTMStartReply, error) {
+	s.RTMStop()
+	r, ws, err := s.rtmConnect(ctx, origin)
 	if err != nil {
 		return nil, err
 	}
+	// The connection context outlives ctx which only bounds the initial connect
+	rtmCtx, stop := context.WithCancel(context.Background())
+	s.mutex.Lock()
+	s.ws, s.info, s.stop = ws, r, stop
+	s.mutex.Unlock()
+	s.state.Reset(r)
+	// Start reading the messages and pumping them to the channel
+	go s.rtmLoop(rtmCtx, ws, origin, in, userContext)
+	return r, nil
+}
+
+// rtmConnect calls rtm.start and dials the websocket
+func (s *Slack) rtmConnect(ctx context.Context, origin string) (*RTMStartReply, *websocket.Conn, error) {
+	r := &RTMStartReply{}
+	err := s.do(ctx, "rtm.start", url.Values{}, r)
+	if err != nil {
+		return nil, nil, err
+	}
 	header := http.Header{"Origin": {origin}}
-	s.ws, _, err = websocket.DefaultDialer.Dial(r.URL, header)
+	ws, _, err := websocket.DefaultDialer.DialContext(ctx, r.URL, header)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	// Start reading the messages and pumping them to the channel
-	go func(ws *websocket.Conn, in chan *Message) {
-		defer func() {
-			if err := recover(); err != nil {
-				s.errorlog.Printf("Recovered from error, %v\n", err)
-				errMsg := gerr.Wrap(err, 2).ErrorStack()
-				s.errorlog.Println(errMsg)
+	return r, ws, nil
+}
+
+// rtmLoop reads the websocket until it drops and reconnects if we are in managed mode
+func (s *Slack) rtmLoop(ctx context.Context, ws *websocket.Conn, origin string, in chan *Message, userContext interface{}) {
+	if s.rtm.reconnect {
+		s.rtmEvent(in, RTMConnected, nil, userContext)
+	}
+	for {
+		err := s.rtmRead(ws, in, userContext)
+		s.failPending(err)
+		if !s.rtm.reconnect {
+			s.rtmEvent(in, "error", err, userContext)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		s.rtmEvent(in, RTMDisconnected, err, userContext)
+		if ws = s.rtmReconnect(ctx, origin, in, userContext); ws == nil {
+			return
+		}
+	}
+}
+
+// rtmReconnect keeps trying to reconnect with jittered exponential backoff until
+// it succeeds or RTMStop cancels the context in which case nil is returned
+func (s *Slack) rtmReconnect(ctx context.Context, origin string, in chan *Message, userContext interface{}) *websocket.Conn {
+	backoff := s.rtm.minBackoff
+	for {
+		s.rtmEvent(in, RTMConnecting, nil, userContext)
+		r, ws, err := s.rtmConnect(ctx, origin)
+		if err == nil {
+			s.mutex.Lock()
+			if ctx.Err() != nil {
+				// Stopped while we were connecting
+				s.mutex.Unlock()
+				ws.Close()
+				return nil
 			}
-			ws.Close()
-		}()
-		// Make sure we are receiving pongs
-		// ws.SetReadDeadline(t)
-		for {
-			msg := &Message{}
-			var unmarshallError bool
-			// Manually read the next message so that if there is JSON error we can
-			// dump the error to log
-			// This is a hack because the events have different fields with different structs
-			// while initially we defined just a simple Message event so now we need to translate
-			// the various events to message to keep compatibility
-			_, p, err := ws.ReadMessage()
-			if err == nil {
-				typeMsg := &baseTypeMessage{}
-				err = json.Unmarshal(p, typeMsg)
-				// Ignore specific messages like user_change for now
+			s.ws, s.info = ws, r
+			s.mutex.Unlock()
+			s.state.Reset(r)
+			s.rtmEvent(in, RTMConnected, nil, userContext)
+			return ws
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		s.errorf("Error reconnecting to RTM - %v\n", err)
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		if rl, ok := err.(*RateLimitedError); ok && rl.RetryAfter > wait {
+			wait = rl.RetryAfter
+		}
+		if sleepContext(ctx, wait) != nil {
+			return nil
+		}
+		if backoff *= 2; backoff > s.rtm.maxBackoff {
+			backoff = s.rtm.maxBackoff
+		}
+	}
+}
+
+// rtmEvent sends a lifecycle or error event on the in channel and the events channel
+func (s *Slack) rtmEvent(in chan *Message, eventType string, err error, userContext interface{}) {
+	if in != nil {
+		msg := &Message{Type: eventType, Context: userContext}
+		if err != nil {
+			msg.Error.Msg = err.Error()
+		}
+		in <- msg
+	}
+	if s.rtm.events != nil {
+		if eventType == "error" {
+			e := &ErrorEvent{}
+			e.Type = eventType
+			e.Error.Msg = err.Error()
+			s.rtm.events <- e
+			return
+		}
+		e := &LifecycleEvent{}
+		e.Type = eventType
+		if err != nil {
+			e.Error = err.Error()
+		}
+		s.rtm.events <- e
+	}
+}
+
+// decodeRTMEvent decodes the raw event and returns an error event if it cannot be decoded
+func (s *Slack) decodeRTMEvent(p []byte) Event {
+	ev, err := DecodeEvent(p)
+	if err != nil {
+		s.errorf("Error unmarshaling event - %s\n", string(p))
+		e := &ErrorEvent{}
+		e.Type = "error"
+		e.Error.Msg = err.Error()
+		e.Error.Unmarshall = true
+		return e
+	}
+	return ev
+}
+
+// rtmRead pumps the messages from the websocket to the channel until reading fails.
+// Returns the error that stopped the reading.
+func (s *Slack) rtmRead(ws *websocket.Conn, in chan *Message, userContext interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.errorf("Recovered from error, %v\n", r)
+			s.errorf("%s\n", gerr.Wrap(r, 2).ErrorStack())
+			err = fmt.Errorf("%v", r)
+		}
+		ws.Close()
+	}()
+	// Make sure we are receiving pongs
+	var k *keepalive
+	if s.rtm.ping > 0 {
+		k = &keepalive{pending: make(map[int]time.Time)}
+		done := make(chan struct{})
+		defer close(done)
+		ws.SetReadDeadline(time.Now().Add(s.rtm.timeout))
+		ws.SetPongHandler(func(string) error {
+			return ws.SetReadDeadline(time.Now().Add(s.rtm.timeout))
+		})
+		go s.rtmKeepalive(ws, k, done)
+	}
+	for {
+		msg := &Message{}
+		// Manually read the next message so that if there is JSON error we can
+		// dump the error to log
+		// This is a hack because the events have different fields with different structs
+		// while initially we defined just a simple Message event so now we need to translate
+		// the various events to message to keep compatibility
+		_, p, err := ws.ReadMessage()
+		if err != nil {
+			if ne, ok := err.(net.Error); k != nil && (k.isDead() || ok && ne.Timeout()) {
+				return ErrRTMTimeout
+			}
+			return err
+		}
+		if k != nil {
+			ws.SetReadDeadline(time.Now().Add(s.rtm.timeout))
+		}
+		typeMsg := &baseTypeMessage{}
+		err = json.Unmarshal(p, typeMsg)
+		if err == nil && k != nil && typeMsg.Type == "pong" {
+			// Our own keepalive pongs are not passed on
+			if rtt, ok := k.pong(typeMsg.ReplyTo); ok {
+				s.tracef("RTM pong %d received after %v\n", typeMsg.ReplyTo, rtt)
+				continue
+			}
+		}
+		if err == nil && typeMsg.Type == "" && typeMsg.ReplyTo > 0 {
+			reply := &WSMessageResponse{}
+			if json.Unmarshal(p, reply) == nil {
+				s.resolvePending(reply.ReplyTo, reply, nil)
+			}
+		}
+		ev := s.decodeRTMEvent(p)
+		s.state.Apply(ev)
+		if s.rtm.events != nil {
+			s.rtm.events <- ev
+		}
+		if in == nil {
+			continue
+		}
+		// Ignore specific messages like user_change for now
+		if err == nil {
+			switch typeMsg.Type {
+			case "channel_created", "channel_joined", "channel_rename", "im_created", "group_joined", "group_left", "group_rename":
+				channelEvent := &ChannelEvent{}
+				err = json.Unmarshal(p, channelEvent)
 				if err == nil {
-					switch typeMsg.Type {
-					case "channel_created", "channel_joined", "channel_rename", "im_created", "group_joined", "group_left", "group_rename":
-						channelEvent := &ChannelEvent{}
-						err = json.Unmarshal(p, channelEvent)
-						if err == nil {
-							msg.Type = channelEvent.Type
-							msg.Channel = channelEvent.Channel.ID
-							msg.User = channelEvent.Channel.Creator
-							msg.Name = channelEvent.Channel.Name
-						}
-					case "user_change", "team_join":
-						userEvent := &UserEvent{}
-						err = json.Unmarshal(p, userEvent)
-						if err == nil {
-							msg.Type = userEvent.Type
-							msg.User = userEvent.User.ID
-							msg.Name = userEvent.User.Name
-						}
-					default:
-						err = json.Unmarshal(p, msg)
-					}
+					msg.Type = channelEvent.Type
+					msg.Channel = channelEvent.Channel.ID
+					msg.User = channelEvent.Channel.Creator
+					msg.Name = channelEvent.Channel.Name
 				}
-				if err == io.EOF {
-					// One value is expected in the message.
-					err = io.ErrUnexpectedEOF
-				}
-				if err != nil {
-					s.errorf("Error unmarshaling message - %s\n", string(p))
-					unmarshallError = true
+			case "user_change", "team_join":
+				userEvent := &UserEvent{}
+				err = json.Unmarshal(p, userEvent)
+				if err == nil {
+					msg.Type = userEvent.Type
+					msg.User = userEvent.User.ID
+					msg.Name = userEvent.User.Name
 				}
-			}
-			// err := ws.ReadJSON(msg)
-			if err != nil {
-				msg.Type = "error"
-				msg.Error.Code, msg.Error.Msg = 0, err.Error()
-				msg.Error.Unmarshall = unmarshallError
-			}
-			// Set the custom data for every message
-			msg.Context = context
-			in <- msg
-			if err != nil && !unmarshallError {
-				break
+			default:
+				err = json.Unmarshal(p, msg)
 			}
 		}
-	}(s.ws, in)
-	return r, nil
+		if err == io.EOF {
+			// One value is expected in the message.
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			s.errorf("Error unmarshaling message - %s\n", string(p))
+			msg.Type = "error"
+			msg.Error.Code, msg.Error.Msg = 0, err.Error()
+			msg.Error.Unmarshall = true
+		}
+		// Set the custom data for every message
+		msg.Context = userContext
+		in <- msg
+	}
+}
+
+// State returns the workspace state which is seeded from the RTMStartReply and
+// kept current by the RTM events
+func (s *Slack) State() *State {
+	return s.state
+}
+
+// RTMInfo returns the latest RTMStartReply. In managed mode it is refreshed after every reconnect.
+func (s *Slack) RTMInfo() *RTMStartReply {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.info
 }
 
 // RTMMessage is sent on the channel for simple text
@@ -137,6 +470,11 @@ type RTMMessage struct {
 
 // RTMSend a simple text message to a channel/group/dm
 func (s *Slack) RTMSend(channel, text string) (int, error) {
+	return s.rtmSend(channel, text, nil)
+}
+
+// rtmSend writes the message and registers the pending acknowledgement if given
+func (s *Slack) rtmSend(channel, text string, pending *RTMPending) (int, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	if s.ws == nil {
@@ -149,11 +487,122 @@ func (s *Slack) RTMSend(channel, text string) (int, error) {
 		Channel: channel,
 		Text:    text,
 	})
+	if err == nil && pending != nil {
+		pending.ID = s.mid
+		if s.pending == nil {
+			s.pending = make(map[int]*RTMPending)
+		}
+		s.pending[s.mid] = pending
+		if s.rtm.ackTimeout > 0 {
+			id := s.mid
+			pending.timer = time.AfterFunc(s.rtm.ackTimeout, func() {
+				s.resolvePending(id, nil, ErrRTMAckTimeout)
+			})
+		}
+	}
 	return s.mid, err
 }
 
+// ErrRTMAckTimeout is returned when a message sent on the RTM is not acknowledged in time - see SetRTMAckTimeout
+var ErrRTMAckTimeout = &Error{ID: "rtm_ack_timeout", Detail: "The RTM message was not acknowledged in time"}
+
+// SetRTMAckTimeout sets how long to wait for the acknowledgement of messages sent with
+// RTMSendAsync and RTMSendWait before failing them with ErrRTMAckTimeout. 0 waits forever.
+func SetRTMAckTimeout(timeout time.Duration) OptionFunc {
+	return func(s *Slack) error {
+		s.rtm.ackTimeout = timeout
+		return nil
+	}
+}
+
+// RTMPending is a message sent on the RTM that waits for its acknowledgement
+type RTMPending struct {
+	ID    int                // The ID of the message
+	done  chan struct{}      // Closed when the acknowledgement arrives or the send fails
+	reply *WSMessageResponse // The acknowledgement
+	err   error              // Why the send failed
+	timer *time.Timer        // Fails the send if not acknowledged in time
+}
+
+// Done is closed when the message is acknowledged or failed
+func (p *RTMPending) Done() <-chan struct{} {
+	return p.done
+}
+
+// Result returns the acknowledgement or the error. It must be called after Done is closed.
+func (p *RTMPending) Result() (*WSMessageResponse, error) {
+	return p.reply, p.err
+}
+
+// Wait blocks until the message is acknowledged, failed or the context is done
+func (p *RTMPending) Wait(ctx context.Context) (*WSMessageResponse, error) {
+	select {
+	case <-p.done:
+		return p.reply, p.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// RTMSendAsync sends a simple text message and returns the pending acknowledgement
+// which is resolved when the matching reply_to frame arrives with the server timestamp.
+func (s *Slack) RTMSendAsync(channel, text string) (*RTMPending, error) {
+	p := &RTMPending{done: make(chan struct{})}
+	if _, err := s.rtmSend(channel, text, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// RTMSendWait sends a simple text message and blocks until it is acknowledged
+func (s *Slack) RTMSendWait(ctx context.Context, channel, text string) (*WSMessageResponse, error) {
+	p, err := s.RTMSendAsync(channel, text)
+	if err != nil {
+		return nil, err
+	}
+	return p.Wait(ctx)
+}
+
+// resolvePending resolves the pending message with the given ID
+func (s *Slack) resolvePending(id int, reply *WSMessageResponse, err error) {
+	s.mutex.Lock()
+	p, ok := s.pending[id]
+	delete(s.pending, id)
+	s.mutex.Unlock()
+	if !ok {
+		return
+	}
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	if err == nil && reply != nil && !reply.OK {
+		err = newError("rtm_send_failed", "%s (%d)", reply.Error.Msg, reply.Error.Code)
+	}
+	p.reply, p.err = reply, err
+	close(p.done)
+}
+
+// failPending fails all the pending messages when the connection drops
+func (s *Slack) failPending(err error) {
+	s.mutex.Lock()
+	ids := make([]int, 0, len(s.pending))
+	for id := range s.pending {
+		ids = append(ids, id)
+	}
+	s.mutex.Unlock()
+	for _, id := range ids {
+		s.resolvePending(id, nil, err)
+	}
+}
+
 // RTMStop closes the WebSocket which in turn closes the in channel passed in RTMStart
 func (s *Slack) RTMStop() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stop != nil {
+		s.stop()
+		s.stop = nil
+	}
 	if s.ws != nil {
 		err := s.ws.Close()
 		s.ws = nil
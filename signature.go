@@ -0,0 +1,67 @@
+package slack
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultReplayWindow is the maximum age of a signed request before it is rejected
+const DefaultReplayWindow = 5 * time.Minute
+
+// maxBodySize limits the size of the requests we read from Slack
+const maxBodySize = 1 << 20
+
+var (
+	// ErrBadSignature is returned when the request signature does not match the signing secret
+	ErrBadSignature = &Error{ID: "bad_signature", Detail: "The request signature is invalid"}
+	// ErrStaleRequest is returned when the request timestamp is outside of the replay window
+	ErrStaleRequest = &Error{ID: "stale_request", Detail: "The request timestamp is outside of the replay window"}
+	// ErrNoSigningSecret is returned when verifying without a signing secret which would accept forged requests
+	ErrNoSigningSecret = &Error{ID: "no_signing_secret", Detail: "A signing secret is required to verify requests"}
+)
+
+// Verifier checks the signature of requests sent by Slack - see https://api.slack.com/authentication/verifying-requests-from-slack
+type Verifier struct {
+	SigningSecret string        // The signing secret of the app. Required.
+	ReplayWindow  time.Duration // Maximum age of a request - DefaultReplayWindow if 0
+}
+
+// Verify reads the body of the request and checks its signature and timestamp.
+// Returns the body which is also put back on the request so it can be read again.
+func (v *Verifier) Verify(r *http.Request) ([]byte, error) {
+	if v.SigningSecret == "" {
+		return nil, ErrNoSigningSecret
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	ts := r.Header.Get("X-Slack-Request-Timestamp")
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, ErrStaleRequest
+	}
+	window := v.ReplayWindow
+	if window == 0 {
+		window = DefaultReplayWindow
+	}
+	age := time.Since(time.Unix(sec, 0))
+	if age > window || age < -window {
+		return nil, ErrStaleRequest
+	}
+	mac := hmac.New(sha256.New, []byte(v.SigningSecret))
+	mac.Write([]byte("v0:" + ts + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(r.Header.Get("X-Slack-Signature"))) {
+		return nil, ErrBadSignature
+	}
+	return body, nil
+}
@@ -0,0 +1,78 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+// signedRequest returns a request signed with the secret at the given time
+func signedRequest(secret, body string, at time.Time) *http.Request {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + ts + ":" + body))
+	r := httptest.NewRequest(http.MethodPost, "/slack/events", strings.NewReader(body))
+	r.Header.Set("X-Slack-Request-Timestamp", ts)
+	r.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return r
+}
+
+func TestVerify(t *testing.T) {
+	body := `{"type":"event_callback"}`
+	v := &Verifier{SigningSecret: testSecret}
+	got, err := v.Verify(signedRequest(testSecret, body, time.Now()))
+	if err != nil {
+		t.Fatalf("valid request rejected - %v", err)
+	}
+	if string(got) != body {
+		t.Errorf("body = %q, want %q", got, body)
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	v := &Verifier{SigningSecret: testSecret}
+	r := signedRequest(testSecret, `{"type":"event_callback"}`, time.Now())
+	r.Body = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"type":"url_verification"}`)).Body
+	if _, err := v.Verify(r); err != ErrBadSignature {
+		t.Errorf("tampered body err = %v, want %v", err, ErrBadSignature)
+	}
+	r = signedRequest("other secret", `{}`, time.Now())
+	if _, err := v.Verify(r); err != ErrBadSignature {
+		t.Errorf("wrong secret err = %v, want %v", err, ErrBadSignature)
+	}
+	r = signedRequest(testSecret, `{}`, time.Now())
+	r.Header.Del("X-Slack-Signature")
+	if _, err := v.Verify(r); err != ErrBadSignature {
+		t.Errorf("missing signature err = %v, want %v", err, ErrBadSignature)
+	}
+}
+
+func TestVerifyStale(t *testing.T) {
+	v := &Verifier{SigningSecret: testSecret}
+	for _, at := range []time.Time{time.Now().Add(-DefaultReplayWindow - time.Minute), time.Now().Add(DefaultReplayWindow + time.Minute)} {
+		if _, err := v.Verify(signedRequest(testSecret, `{}`, at)); err != ErrStaleRequest {
+			t.Errorf("request at %v err = %v, want %v", at, err, ErrStaleRequest)
+		}
+	}
+	r := signedRequest(testSecret, `{}`, time.Now())
+	r.Header.Set("X-Slack-Request-Timestamp", "not a number")
+	if _, err := v.Verify(r); err != ErrStaleRequest {
+		t.Errorf("bad timestamp err = %v, want %v", err, ErrStaleRequest)
+	}
+}
+
+func TestVerifyEmptySecret(t *testing.T) {
+	v := &Verifier{}
+	// A request signed with the empty key must not pass
+	if _, err := v.Verify(signedRequest("", `{}`, time.Now())); err != ErrNoSigningSecret {
+		t.Errorf("empty secret err = %v, want %v", err, ErrNoSigningSecret)
+	}
+}
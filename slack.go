@@ -6,6 +6,8 @@ Written by Slavik Markovich at Demisto
 package slack
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -27,12 +29,13 @@ const (
 
 // Error is returned when there is a known condition error in the API
 type Error struct {
-	ID     string `json:"id"`
-	Detail string `json:"detail"`
+	ID         string `json:"id"`
+	Detail     string `json:"detail"`
+	StatusCode int    `json:"status_code,omitempty"` // The HTTP status for unexpected status codes
 }
 
 func newError(code, format string, args ...interface{}) *Error {
-	return &Error{code, fmt.Sprintf(format, args...)}
+	return &Error{ID: code, Detail: fmt.Sprintf(format, args...)}
 }
 
 func (e *Error) Error() string {
@@ -41,23 +44,30 @@ func (e *Error) Error() string {
 
 var (
 	// ErrBadToken is returned when a bad token is passed to the API
-	ErrBadToken = &Error{"bad_token", "Bad token was provided to the API"}
+	ErrBadToken = &Error{ID: "bad_token", Detail: "Bad token was provided to the API"}
 	// ErrNoToken is returned when the token is missing
-	ErrNoToken = &Error{"no_token", "You must provide a Slack token to use the API"}
+	ErrNoToken = &Error{ID: "no_token", Detail: "You must provide a Slack token to use the API"}
 	// ErrBadOAuth is returned when OAuth credentials are bad
-	ErrBadOAuth = &Error{"bad_oauth", "Bad OAuth credentials provided"}
+	ErrBadOAuth = &Error{ID: "bad_oauth", Detail: "Bad OAuth credentials provided"}
 )
 
 // Slack is the client to the Slack API.
 type Slack struct {
-	token    string          // The token to use for requests. Required.
-	url      string          // The URL for the API.
-	errorlog *log.Logger     // Optional logger to write errors to
-	tracelog *log.Logger     // Optional logger to write trace and debug data to
-	c        *http.Client    // The client to use for requests
-	ws       *websocket.Conn // WS connection
-	mid      int             // WS message ID
-	mutex    sync.Mutex      // WS mutex to protect changes
+	token    string              // The token to use for requests. Required.
+	url      string              // The URL for the API.
+	errorlog *log.Logger         // Optional logger to write errors to
+	tracelog *log.Logger         // Optional logger to write trace and debug data to
+	c        *http.Client        // The client to use for requests
+	ws       *websocket.Conn     // WS connection
+	mid      int                 // WS message ID
+	mutex    sync.Mutex          // WS mutex to protect changes
+	rtm      rtmConfig           // RTM connection options
+	info     *RTMStartReply      // Latest RTM start reply
+	stop     context.CancelFunc  // Cancels the RTM reconnects when RTMStop is called
+	pending  map[int]*RTMPending // WS messages waiting for acknowledgement
+	state    *State              // Workspace state kept current by the RTM
+	retry    RetryPolicy         // How to retry rate limited requests
+	limiter  *RateLimiter        // Optional client side rate limiter
 }
 
 // OptionFunc is a function that configures a Client.
@@ -97,13 +107,28 @@ func (s *Slack) tracef(format string, args ...interface{}) {
 //
 // An error is also returned when some configuration option is invalid.
 func New(options ...OptionFunc) (*Slack, error) {
-	// Set up the client
-	s := &Slack{
-		url: "",
-		c:   http.DefaultClient,
+	s, err := newClient(options)
+	if err != nil {
+		return nil, err
+	}
+	s.tracef("Using URL [%s]\n", s.url)
+
+	// If no API key was specified
+	if s.token == "" {
+		s.errorf("%s\n", ErrNoToken.Error())
+		return nil, ErrNoToken
 	}
 
-	// Run the options on it
+	return s, nil
+}
+
+// newClient sets up the client with the defaults and runs the options on it
+func newClient(options []OptionFunc) (*Slack, error) {
+	s := &Slack{
+		url:   "",
+		c:     http.DefaultClient,
+		state: NewState(nil),
+	}
 	for _, option := range options {
 		if err := option(s); err != nil {
 			return nil, err
@@ -112,14 +137,6 @@ func New(options ...OptionFunc) (*Slack, error) {
 	if s.url == "" {
 		s.url = DefaultURL
 	}
-	s.tracef("Using URL [%s]\n", s.url)
-
-	// If no API key was specified
-	if s.token == "" {
-		s.errorf("%s\n", ErrNoToken.Error())
-		return nil, ErrNoToken
-	}
-
 	return s, nil
 }
 
@@ -175,6 +192,29 @@ func SetURL(rawurl string) OptionFunc {
 	}
 }
 
+// SetRetryPolicy sets the policy used to retry requests that were rate limited by Slack.
+// By default rate limited requests are not retried and a *RateLimitedError is returned.
+func SetRetryPolicy(policy RetryPolicy) OptionFunc {
+	return func(s *Slack) error {
+		if policy.MaxRetries < 0 || policy.MaxWait < 0 {
+			err := newError("bad_retry_policy", "Invalid retry policy %+v", policy)
+			s.errorf("%v\n", err)
+			return err
+		}
+		s.retry = policy
+		return nil
+	}
+}
+
+// SetRateLimiter sets the client side rate limiter consulted before every request.
+// Use NewRateLimiter to get a limiter with the Slack tiers. It is nil by default.
+func SetRateLimiter(limiter *RateLimiter) OptionFunc {
+	return func(s *Slack) error {
+		s.limiter = limiter
+		return nil
+	}
+}
+
 // SetErrorLog sets the logger for critical messages. It is nil by default.
 func SetErrorLog(logger *log.Logger) func(*Slack) error {
 	return func(s *Slack) error {
@@ -202,6 +242,21 @@ func (s *Slack) dumpRequest(req *http.Request) {
 	}
 }
 
+// dumpSecretRequest dumps a request whose URL holds a secret, like a webhook or a response_url,
+// with only the scheme and host of the URL
+func (s *Slack) dumpSecretRequest(req *http.Request) {
+	if s.tracelog != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return
+		}
+		r := req.Clone(req.Context())
+		r.URL = &url.URL{Scheme: req.URL.Scheme, Host: req.URL.Host, Path: "/redacted"}
+		r.Body = body
+		s.dumpRequest(r)
+	}
+}
+
 // dumpResponse dumps a response to the debug logger if it was defined
 func (s *Slack) dumpResponse(resp *http.Response) {
 	if s.tracelog != nil {
@@ -216,6 +271,11 @@ func (s *Slack) dumpResponse(resp *http.Response) {
 
 // handleError will handle responses with status code different from success
 func (s *Slack) handleError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		e := newRateLimitedError(resp)
+		s.errorf("%s\n", e.Error())
+		return e
+	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		if s.errorlog != nil {
 			out, err := httputil.DumpResponse(resp, true)
@@ -224,22 +284,59 @@ func (s *Slack) handleError(resp *http.Response) error {
 			}
 		}
 		e := newError("http_error", "Unexpected status code: %d (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
+		e.StatusCode = resp.StatusCode
 		s.errorf("%s\n", e.Error())
 		return e
 	}
 	return nil
 }
 
-// do executes the API request.
+// do executes the API request bound to the given context.
 // Returns the response if the status code is between 200 and 299
-func (s *Slack) do(path string, params url.Values, result interface{}) error {
+// Rate limited requests are retried according to the retry policy of the client.
+func (s *Slack) do(ctx context.Context, path string, params url.Values, result interface{}) error {
+	if err := checkIDs(path, params); err != nil {
+		s.errorf("%s\n", err.Error())
+		return err
+	}
 	appendNotEmpty("token", s.token, params)
+	for attempt := 0; ; attempt++ {
+		if err := s.wait(ctx, path, params.Get("channel")); err != nil {
+			return err
+		}
+		err := s.doOnce(ctx, path, params, result)
+		rl, ok := err.(*RateLimitedError)
+		if !ok || !s.retry.shouldRetry(attempt, rl.RetryAfter) {
+			return err
+		}
+		s.errorf("Request %s was rate limited, retrying in %v\n", path, rl.RetryAfter)
+		if err = sleepContext(ctx, rl.RetryAfter); err != nil {
+			return err
+		}
+	}
+}
+
+// wait for the rate limiter if one is configured
+func (s *Slack) wait(ctx context.Context, path, channel string) error {
+	if s.limiter == nil {
+		return nil
+	}
+	return s.limiter.Wait(ctx, path, channel)
+}
+
+// doOnce executes a single attempt of the API request
+func (s *Slack) doOnce(ctx context.Context, path string, params url.Values, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", s.url+path, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	var t time.Time
 	if s.tracelog != nil {
 		t = time.Now()
 		s.tracef("Start request %s at %v", path, t)
 	}
-	resp, err := s.c.PostForm(s.url+path, params)
+	resp, err := s.c.Do(req)
 	if s.tracelog != nil {
 		s.tracef("End request %s at %v - took %v", path, time.Now(), time.Since(t))
 	}
@@ -265,6 +362,9 @@ func (s *Slack) do(path string, params url.Values, result interface{}) error {
 			// Handle ok response parameter
 			if !result.IsOK() {
 				s.errorf("%s\n", result.Error())
+				if result.Error() == "ratelimited" {
+					return newRateLimitedError(resp)
+				}
 				return result
 			}
 		default:
@@ -277,6 +377,59 @@ func (s *Slack) do(path string, params url.Values, result interface{}) error {
 	return nil
 }
 
+// postJSON posts the JSON body to an absolute URL like a response_url.
+// Failures are returned as *Error with the reason Slack sent as the ID when it sends one.
+// The URL holds a secret so only its host is traced.
+func (s *Slack) postJSON(ctx context.Context, rawurl string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", rawurl, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.dumpSecretRequest(req)
+	resp, err := s.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	s.dumpResponse(resp)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return newRateLimitedError(resp)
+	}
+	text, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return err
+	}
+	reason := strings.TrimSpace(string(text))
+	if strings.HasPrefix(reason, "{") {
+		r := &slackResponse{}
+		if json.Unmarshal(text, r) == nil && !r.IsOK() && r.Error() != "" {
+			reason = r.Error()
+		} else {
+			reason = ""
+		}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if reason == "" {
+			reason = "http_error"
+		}
+		e := newError(reason, "Unexpected status code: %d (%s)", resp.StatusCode, http.StatusText(resp.StatusCode))
+		e.StatusCode = resp.StatusCode
+		s.errorf("%s\n", e.Error())
+		return e
+	}
+	if reason != "" && reason != "ok" {
+		e := newError(reason, "Request to %s failed", req.URL.Host)
+		s.errorf("%s\n", e.Error())
+		return e
+	}
+	return nil
+}
+
 // Helper functions
 
 func appendNotEmpty(name, val string, params url.Values) {
@@ -0,0 +1,182 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Response types for slash commands and interactive responses
+const (
+	ResponseInChannel = "in_channel"
+	ResponseEphemeral = "ephemeral"
+)
+
+// SlashCommand holds the payload Slack sends when a slash command is invoked - see https://api.slack.com/interactivity/slash-commands
+type SlashCommand struct {
+	Token          string `json:"token"`
+	TeamID         string `json:"team_id"`
+	TeamDomain     string `json:"team_domain"`
+	EnterpriseID   string `json:"enterprise_id,omitempty"`
+	EnterpriseName string `json:"enterprise_name,omitempty"`
+	ChannelID      string `json:"channel_id"`
+	ChannelName    string `json:"channel_name"`
+	UserID         string `json:"user_id"`
+	UserName       string `json:"user_name"`
+	Command        string `json:"command"`
+	Text           string `json:"text"`
+	ResponseURL    string `json:"response_url"`
+	TriggerID      string `json:"trigger_id"`
+	APIAppID       string `json:"api_app_id"`
+}
+
+// ParseSlashCommand parses the form values of a slash command request
+func ParseSlashCommand(form url.Values) *SlashCommand {
+	return &SlashCommand{
+		Token:          form.Get("token"),
+		TeamID:         form.Get("team_id"),
+		TeamDomain:     form.Get("team_domain"),
+		EnterpriseID:   form.Get("enterprise_id"),
+		EnterpriseName: form.Get("enterprise_name"),
+		ChannelID:      form.Get("channel_id"),
+		ChannelName:    form.Get("channel_name"),
+		UserID:         form.Get("user_id"),
+		UserName:       form.Get("user_name"),
+		Command:        form.Get("command"),
+		Text:           form.Get("text"),
+		ResponseURL:    form.Get("response_url"),
+		TriggerID:      form.Get("trigger_id"),
+		APIAppID:       form.Get("api_app_id"),
+	}
+}
+
+// SlashCommand decodes the payload of a slash_commands Socket Mode envelope
+func (req *SocketModeRequest) SlashCommand() (*SlashCommand, error) {
+	cmd := &SlashCommand{}
+	if err := json.Unmarshal(req.Payload, cmd); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// SlashResponse is the immediate or delayed response to a slash command
+type SlashResponse struct {
+	ResponseType    string       `json:"response_type,omitempty"` // ResponseInChannel or ResponseEphemeral (the default)
+	Text            string       `json:"text,omitempty"`
+	Attachments     []Attachment `json:"attachments,omitempty"`
+	Blocks          Blocks       `json:"blocks,omitempty"`
+	ReplaceOriginal bool         `json:"replace_original,omitempty"`
+	DeleteOriginal  bool         `json:"delete_original,omitempty"`
+}
+
+// SlashHandlerFunc handles a slash command and returns the immediate response which can be
+// nil to just acknowledge the command and respond later via RespondURL
+type SlashHandlerFunc func(cmd *SlashCommand) (*SlashResponse, error)
+
+// SlashHandler is an http.Handler receiving slash commands. It verifies the request
+// signature and routes the commands by name to the registered handlers.
+type SlashHandler struct {
+	Verifier
+	ErrorLog *log.Logger // Optional logger to write rejected requests and handler errors to
+	mutex    sync.RWMutex
+	commands map[string]SlashHandlerFunc // Handlers by command name including the slash
+}
+
+// NewSlashHandler creates a handler for the given signing secret. With an empty secret
+// every request is rejected with ErrNoSigningSecret.
+func NewSlashHandler(signingSecret string) *SlashHandler {
+	return &SlashHandler{Verifier: Verifier{SigningSecret: signingSecret}, commands: make(map[string]SlashHandlerFunc)}
+}
+
+// Handle registers the handler for the given command, for example "/deploy"
+func (h *SlashHandler) Handle(command string, fn SlashHandlerFunc) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.commands == nil {
+		h.commands = make(map[string]SlashHandlerFunc)
+	}
+	h.commands[command] = fn
+}
+
+// errorf logs to the error log if defined
+func (h *SlashHandler) errorf(format string, args ...interface{}) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, args...)
+	}
+}
+
+// ServeHTTP handles the slash command request
+func (h *SlashHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := h.Verify(r)
+	if err != nil {
+		h.errorf("Rejected slash command request - %v\n", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cmd := ParseSlashCommand(form)
+	h.mutex.RLock()
+	fn, ok := h.commands[cmd.Command]
+	h.mutex.RUnlock()
+	if !ok {
+		h.errorf("No handler for slash command %s\n", cmd.Command)
+		writeJSON(w, &SlashResponse{ResponseType: ResponseEphemeral, Text: "Unknown command " + cmd.Command})
+		return
+	}
+	resp, err := fn(cmd)
+	if err != nil {
+		h.errorf("Error handling slash command %s - %v\n", cmd.Command, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if resp == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	writeJSON(w, resp)
+}
+
+// writeJSON writes the value as a JSON response
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// RespondURL posts a delayed response to the response_url of a slash command or interaction
+func (s *Slack) RespondURL(responseURL string, r *SlashResponse) error {
+	return s.RespondURLContext(context.Background(), responseURL, r)
+}
+
+// RespondURLContext is RespondURL with a custom context
+func (s *Slack) RespondURLContext(ctx context.Context, responseURL string, r *SlashResponse) error {
+	if responseURL == "" {
+		return newError("bad_response_url", "Missing response URL")
+	}
+	return s.postJSON(ctx, responseURL, r)
+}
+
+// RespondURL posts a delayed response to the response_url using the default HTTP client.
+// It does not require a token.
+func RespondURL(responseURL string, r *SlashResponse) error {
+	return RespondURLContext(context.Background(), responseURL, r)
+}
+
+// RespondURLContext is RespondURL with a custom context
+func RespondURLContext(ctx context.Context, responseURL string, r *SlashResponse) error {
+	s := &Slack{
+		url: DefaultURL,
+		c:   http.DefaultClient,
+	}
+	return s.RespondURLContext(ctx, responseURL, r)
+}
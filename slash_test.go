@@ -0,0 +1,22 @@
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSlashHandlerEmptySecret(t *testing.T) {
+	called := false
+	h := NewSlashHandler("")
+	h.Handle("/deploy", func(*SlashCommand) (*SlashResponse, error) {
+		called = true
+		return nil, nil
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, signedRequest("", "command=%2Fdeploy&text=prod", time.Now()))
+	if w.Code != http.StatusUnauthorized || called {
+		t.Errorf("empty secret status = %d, called = %v, want %d and no call", w.Code, called, http.StatusUnauthorized)
+	}
+}
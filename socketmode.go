@@ -0,0 +1,252 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/url"
+	"sync"
+	"time"
+
+	gerr "github.com/go-errors/errors"
+	"github.com/gorilla/websocket"
+)
+
+// Socket Mode envelope types - see https://api.slack.com/apis/connections/socket-implement
+const (
+	SocketModeHello         = "hello"
+	SocketModeDisconnect    = "disconnect"
+	SocketModeEventsAPI     = "events_api"
+	SocketModeSlashCommands = "slash_commands"
+	SocketModeInteractive   = "interactive"
+)
+
+// AppsConnectionsOpenResponse is the response to apps.connections.open
+type AppsConnectionsOpenResponse struct {
+	slackResponse
+	URL string `json:"url"`
+}
+
+// AppsConnectionsOpen returns the websocket URL for Socket Mode. The client must be
+// created with an app level token (xapp-...).
+func (s *Slack) AppsConnectionsOpen() (*AppsConnectionsOpenResponse, error) {
+	return s.AppsConnectionsOpenContext(context.Background())
+}
+
+// AppsConnectionsOpenContext is AppsConnectionsOpen with a custom context
+func (s *Slack) AppsConnectionsOpenContext(ctx context.Context) (*AppsConnectionsOpenResponse, error) {
+	r := &AppsConnectionsOpenResponse{}
+	err := s.do(ctx, "apps.connections.open", url.Values{}, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SocketModeRequest is an envelope received on the Socket Mode connection
+type SocketModeRequest struct {
+	Type                   string          `json:"type"`
+	EnvelopeID             string          `json:"envelope_id,omitempty"`
+	Payload                json.RawMessage `json:"payload,omitempty"`
+	AcceptsResponsePayload bool            `json:"accepts_response_payload,omitempty"`
+	RetryAttempt           int             `json:"retry_attempt,omitempty"`
+	RetryReason            string          `json:"retry_reason,omitempty"`
+	Reason                 string          `json:"reason,omitempty"`          // Why we are asked to disconnect
+	NumConnections         int             `json:"num_connections,omitempty"` // Sent with hello
+}
+
+// socketModeAck acknowledges an envelope
+type socketModeAck struct {
+	EnvelopeID string      `json:"envelope_id"`
+	Payload    interface{} `json:"payload,omitempty"`
+}
+
+// SocketModeHandler handles an envelope and returns the optional payload sent with the
+// acknowledgement, for example the response to a slash command. Returning an error acks
+// the envelope without a payload.
+type SocketModeHandler func(req *SocketModeRequest) (interface{}, error)
+
+// SocketMode is a client for the Socket Mode API. It opens the connection with
+// apps.connections.open, acknowledges every envelope by its ID, reconnects when
+// Slack asks it to or the connection drops and delivers the envelopes to the
+// handlers registered by type.
+//
+// Example:
+//
+//	sm, err := slack.NewSocketMode("xapp-...", slack.SetErrorLog(logger))
+//	sm.OnEventsAPI(func(cb *slack.EventCallback) { ... })
+//	err = sm.Run(ctx)
+type SocketMode struct {
+	s        *Slack                       // Client using the app level token
+	mutex    sync.Mutex                   // Protects the handlers and writes to the websocket
+	handlers map[string]SocketModeHandler // Handlers by envelope type
+}
+
+// NewSocketMode creates a Socket Mode client for the given app level token. The options
+// are the same as for New, for example SetHTTPClient or SetErrorLog.
+func NewSocketMode(appToken string, options ...OptionFunc) (*SocketMode, error) {
+	s, err := New(append(options, SetToken(appToken))...)
+	if err != nil {
+		return nil, err
+	}
+	return &SocketMode{s: s, handlers: make(map[string]SocketModeHandler)}, nil
+}
+
+// Handle registers the handler for the given envelope type
+func (sm *SocketMode) Handle(envelopeType string, h SocketModeHandler) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	sm.handlers[envelopeType] = h
+}
+
+// OnEventsAPI registers the handler for Events API envelopes. The envelope is acknowledged
+// before the handler is called.
+func (sm *SocketMode) OnEventsAPI(h func(*EventCallback)) {
+	sm.Handle(SocketModeEventsAPI, func(req *SocketModeRequest) (interface{}, error) {
+		cb, err := ParseEventCallback(req.Payload)
+		if err != nil {
+			return nil, err
+		}
+		cb.RetryNum, cb.RetryReason = req.RetryAttempt, req.RetryReason
+		h(cb)
+		return nil, nil
+	})
+}
+
+// OnSlashCommand registers the handler for slash command envelopes
+func (sm *SocketMode) OnSlashCommand(h SocketModeHandler) {
+	sm.Handle(SocketModeSlashCommands, h)
+}
+
+// OnInteractive registers the handler for interactive envelopes
+func (sm *SocketMode) OnInteractive(h SocketModeHandler) {
+	sm.Handle(SocketModeInteractive, h)
+}
+
+// Run connects and handles the envelopes until the context is done
+func (sm *SocketMode) Run(ctx context.Context) error {
+	backoff := time.Second
+	for {
+		ws, err := sm.connect(ctx)
+		if err == nil {
+			backoff = time.Second
+			err = sm.read(ctx, ws)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			sm.s.errorf("Socket Mode connection failed, reconnecting - %v\n", err)
+		}
+		// When Slack asks us to reconnect the backoff was reset so this is the jittered minimum
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		if rl, ok := err.(*RateLimitedError); ok && rl.RetryAfter > wait {
+			wait = rl.RetryAfter
+		}
+		if err != nil {
+			if backoff *= 2; backoff > time.Minute {
+				backoff = time.Minute
+			}
+		}
+		if err = sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// connect opens a new Socket Mode websocket
+func (sm *SocketMode) connect(ctx context.Context) (*websocket.Conn, error) {
+	r, err := sm.s.AppsConnectionsOpenContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ws, _, err := websocket.DefaultDialer.DialContext(ctx, r.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// read handles the envelopes until the connection drops, Slack asks us to disconnect
+// (in which case nil is returned) or the context is done
+func (sm *SocketMode) read(ctx context.Context, ws *websocket.Conn) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			ws.Close()
+		case <-done:
+		}
+	}()
+	defer ws.Close()
+	for {
+		_, p, err := ws.ReadMessage()
+		if err != nil {
+			return err
+		}
+		req := &SocketModeRequest{}
+		if err = json.Unmarshal(p, req); err != nil {
+			sm.s.errorf("Error unmarshaling Socket Mode envelope - %s\n", string(p))
+			continue
+		}
+		switch req.Type {
+		case SocketModeHello:
+			sm.s.tracef("Socket Mode connected with %d connections\n", req.NumConnections)
+		case SocketModeDisconnect:
+			sm.s.tracef("Socket Mode disconnect requested - %s\n", req.Reason)
+			return nil
+		default:
+			go sm.handle(ws, req)
+		}
+	}
+}
+
+// handle calls the handler for the envelope and acknowledges it
+func (sm *SocketMode) handle(ws *websocket.Conn, req *SocketModeRequest) {
+	sm.mutex.Lock()
+	h := sm.handlers[req.Type]
+	sm.mutex.Unlock()
+	if req.Type == SocketModeEventsAPI {
+		// Events do not have a response payload so ack them right away
+		sm.ack(ws, req, nil)
+		if h != nil {
+			sm.call(h, req)
+		}
+		return
+	}
+	var payload interface{}
+	if h != nil {
+		payload = sm.call(h, req)
+	}
+	sm.ack(ws, req, payload)
+}
+
+// call calls the handler and recovers from any panic
+func (sm *SocketMode) call(h SocketModeHandler, req *SocketModeRequest) (payload interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			sm.s.errorf("Recovered from panic handling %s envelope, %v\n", req.Type, r)
+			sm.s.errorf("%s\n", gerr.Wrap(r, 2).ErrorStack())
+			payload = nil
+		}
+	}()
+	payload, err := h(req)
+	if err != nil {
+		sm.s.errorf("Error handling %s envelope %s - %v\n", req.Type, req.EnvelopeID, err)
+		return nil
+	}
+	return payload
+}
+
+// ack acknowledges the envelope with the optional payload
+func (sm *SocketMode) ack(ws *websocket.Conn, req *SocketModeRequest, payload interface{}) {
+	if req.EnvelopeID == "" {
+		return
+	}
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	if err := ws.WriteJSON(&socketModeAck{EnvelopeID: req.EnvelopeID, Payload: payload}); err != nil {
+		sm.s.errorf("Error acknowledging envelope %s - %v\n", req.EnvelopeID, err)
+	}
+}
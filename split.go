@@ -0,0 +1,173 @@
+package slack
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxMessageLength is the text length above which PostLongMessage splits the text
+const MaxMessageLength = 4000
+
+// minSplitLength is the smallest supported part length so a fence and some text always fit
+const minSplitLength = 16
+
+// codeFence starts and ends a mrkdwn code block
+const codeFence = "```"
+
+// SplitText splits the text into parts of at most max bytes. It splits on line boundaries
+// when possible, then on spaces and never inside a UTF-8 character or an escaped entity
+// like &amp;. A code block spanning parts is closed at the end of the part and reopened at
+// the start of the next one so every part renders correctly.
+func SplitText(text string, max int) []string {
+	if max < minSplitLength {
+		max = minSplitLength
+	}
+	if len(text) <= max {
+		return []string{text}
+	}
+	var parts []string
+	var b strings.Builder
+	inFence := false
+	start := 0 // Length of the reopened fence at the start of the current part
+	flush := func() {
+		part := b.String()
+		if inFence {
+			if strings.HasSuffix(part, codeFence) {
+				// The block opens at the very end so open it in the next part instead
+				part = strings.TrimRight(strings.TrimSuffix(part, codeFence), "\n")
+			} else {
+				part += "\n" + codeFence
+			}
+		}
+		if !onlyFences(part) {
+			parts = append(parts, part)
+		}
+		b.Reset()
+		start = 0
+		if inFence {
+			b.WriteString(codeFence)
+			start = len(codeFence)
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		for {
+			prefix := ""
+			if b.Len() > 0 {
+				prefix = "\n"
+			}
+			after := toggleFence(inFence, line)
+			reserve := 0
+			if after {
+				reserve = len(codeFence) + 1
+			}
+			if b.Len()+len(prefix)+len(line)+reserve <= max {
+				b.WriteString(prefix)
+				b.WriteString(line)
+				inFence = after
+				break
+			}
+			if b.Len() > start {
+				// Start a new part and retry the line there
+				flush()
+				continue
+			}
+			// The line alone is too long
+			room := max - b.Len() - len(prefix) - len(codeFence) - 1
+			cut, skip := cutPoint(line, room)
+			b.WriteString(prefix)
+			b.WriteString(line[:cut])
+			inFence = toggleFence(inFence, line[:cut])
+			flush()
+			line = line[cut+skip:]
+		}
+	}
+	if b.Len() > start && !onlyFences(b.String()) {
+		parts = append(parts, b.String())
+	}
+	return parts
+}
+
+// onlyFences returns true if the part holds nothing but fence markers and white space
+func onlyFences(part string) bool {
+	return strings.TrimSpace(strings.Replace(part, codeFence, "", -1)) == ""
+}
+
+// toggleFence returns the code block state after the given text
+func toggleFence(inFence bool, s string) bool {
+	if strings.Count(s, codeFence)%2 == 1 {
+		return !inFence
+	}
+	return inFence
+}
+
+// cutPoint returns where to cut the line so the head fits the room and how many bytes
+// to skip after the cut
+func cutPoint(s string, room int) (int, int) {
+	if room >= len(s) {
+		return len(s), 0
+	}
+	cut := room
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	if i := strings.LastIndexByte(s[:cut], ' '); i > cut/2 {
+		return i, 1
+	}
+	// Do not break an escaped entity
+	if i := strings.LastIndexByte(s[:cut], '&'); i > 0 && !strings.Contains(s[i:cut], ";") {
+		if j := strings.IndexByte(s[i:], ';'); j > 0 && j < 8 {
+			cut = i
+		}
+	}
+	// Never cut inside a fence marker which would break the code block in both parts
+	for i := cut - len(codeFence) + 1; i < cut; i++ {
+		if i >= 0 && strings.HasPrefix(s[i:], codeFence) {
+			cut = i
+			break
+		}
+	}
+	if cut == 0 {
+		if strings.HasPrefix(s, codeFence) {
+			cut = len(codeFence)
+		} else {
+			_, cut = utf8.DecodeRuneInString(s)
+		}
+	}
+	return cut, 0
+}
+
+// PostLongMessage posts the message text split into parts of at most MaxMessageLength as
+// sequential messages. With threaded, the parts after the first are posted in the thread
+// of the first message unless m.ThreadID is already set. The attachments and blocks are
+// posted with the last part. The replies of the posted parts are returned even on error.
+func (s *Slack) PostLongMessage(m *PostMessageRequest, escape, threaded bool) ([]*PostMessageReply, error) {
+	return s.PostLongMessageContext(context.Background(), m, escape, threaded)
+}
+
+// PostLongMessageContext is PostLongMessage with a custom context
+func (s *Slack) PostLongMessageContext(ctx context.Context, m *PostMessageRequest, escape, threaded bool) ([]*PostMessageReply, error) {
+	text := m.Text
+	if escape {
+		// Escape before splitting so the parts are measured as sent
+		text = escapeText(text)
+	}
+	parts := SplitText(text, MaxMessageLength)
+	replies := make([]*PostMessageReply, 0, len(parts))
+	for i, part := range parts {
+		p := *m
+		p.Text = part
+		if i < len(parts)-1 {
+			p.Attachments, p.Blocks = nil, nil
+		}
+		if threaded && i > 0 && m.ThreadID == "" {
+			p.ThreadID = replies[0].Timestamp
+		}
+		r, err := s.PostMessageContext(ctx, &p, false)
+		if err != nil {
+			return replies, err
+		}
+		replies = append(replies, r)
+	}
+	return replies, nil
+}
@@ -0,0 +1,94 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// checkParts verifies the invariants every part must keep
+func checkParts(t *testing.T, text string, max int, parts []string) {
+	t.Helper()
+	for i, part := range parts {
+		if len(part) > max {
+			t.Errorf("part %d of %q is %d bytes, more than %d", i, text, len(part), max)
+		}
+		if !utf8.ValidString(part) {
+			t.Errorf("part %d of %q is not valid UTF-8: %q", i, text, part)
+		}
+		if strings.Count(part, codeFence)%2 != 0 {
+			t.Errorf("part %d of %q has an unbalanced code block: %q", i, text, part)
+		}
+		if onlyFences(part) {
+			t.Errorf("part %d of %q holds only fence markers: %q", i, text, part)
+		}
+		if amp := strings.LastIndexByte(part, '&'); amp >= 0 && amp > len(part)-6 && !strings.Contains(part[amp:], ";") && strings.Contains(text, part[amp:]+"amp;") {
+			t.Errorf("part %d of %q cuts an entity: %q", i, text, part)
+		}
+	}
+}
+
+// stripFences returns the text without fences and white space to compare the content
+func stripFences(s string) string {
+	return strings.Join(strings.Fields(strings.Replace(s, codeFence, "", -1)), "")
+}
+
+func TestSplitTextShort(t *testing.T) {
+	parts := SplitText("hello", 100)
+	if len(parts) != 1 || parts[0] != "hello" {
+		t.Errorf("SplitText = %q, want [hello]", parts)
+	}
+}
+
+func TestSplitTextFences(t *testing.T) {
+	tests := []string{
+		"xxxxxxxxxx```" + strings.Repeat("y", 30) + "```",
+		"intro\n```\n```",
+		"intro text here\n```\n" + strings.Repeat("code line\n", 6) + "```\nafter",
+		"```" + strings.Repeat("z", 50) + "```",
+		"aaaaaaaaaaaaa````" + strings.Repeat("b", 20) + "```",
+	}
+	for _, text := range tests {
+		parts := SplitText(text, 16)
+		checkParts(t, text, 16, parts)
+		if got, want := stripFences(strings.Join(parts, "")), stripFences(text); got != want {
+			t.Errorf("SplitText(%q) lost content: %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestSplitTextFenceMarkerNotCut(t *testing.T) {
+	text := "xxxxxxxxxx```" + strings.Repeat("y", 30) + "```"
+	for _, part := range SplitText(text, 16) {
+		if strings.HasSuffix(part, "``") && !strings.HasSuffix(part, codeFence) || strings.HasPrefix(part, "`") && !strings.HasPrefix(part, codeFence) {
+			t.Errorf("part %q cuts a fence marker", part)
+		}
+	}
+}
+
+func TestSplitTextRunes(t *testing.T) {
+	text := strings.Repeat("héllo wörld ✓ ", 10) + strings.Repeat("日本語", 20)
+	for _, max := range []int{16, 17, 18, 31} {
+		parts := SplitText(text, max)
+		checkParts(t, text, max, parts)
+		if got := strings.Join(strings.Fields(strings.Join(parts, " ")), ""); got != strings.Join(strings.Fields(text), "") {
+			t.Errorf("SplitText(max %d) lost content", max)
+		}
+	}
+}
+
+func TestSplitTextEntities(t *testing.T) {
+	text := strings.Repeat("a&amp;b", 20)
+	for _, max := range []int{16, 17, 18, 19, 20} {
+		parts := SplitText(text, max)
+		checkParts(t, text, max, parts)
+		for _, part := range parts {
+			if strings.Count(part, "&") != strings.Count(part, "&amp;") {
+				t.Errorf("SplitText(max %d) cut an entity: %q", max, part)
+			}
+		}
+		if got := strings.Join(parts, ""); got != text {
+			t.Errorf("SplitText(max %d) = %q, want %q joined", max, got, text)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package slack
+
+import "sync"
+
+// State is a concurrency safe cache of the workspace. It is seeded from the RTMStartReply
+// and kept current by applying the RTM events. The RTM keeps the state of the client
+// current automatically - see (*Slack).State.
+type State struct {
+	mutex    sync.RWMutex
+	team     Team
+	channels map[string]*Channel
+	groups   map[string]*Group
+	ims      map[string]*IM
+	users    map[string]*User
+	bots     map[string]*Bot
+}
+
+// NewState creates a new state seeded from the reply which can be nil
+func NewState(r *RTMStartReply) *State {
+	st := &State{}
+	st.Reset(r)
+	return st
+}
+
+// Reset replaces the state with the snapshot in the reply
+func (st *State) Reset(r *RTMStartReply) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.team = Team{}
+	st.channels = make(map[string]*Channel)
+	st.groups = make(map[string]*Group)
+	st.ims = make(map[string]*IM)
+	st.users = make(map[string]*User)
+	st.bots = make(map[string]*Bot)
+	if r == nil {
+		return
+	}
+	st.team = r.Team
+	for i := range r.Channels {
+		c := r.Channels[i]
+		st.channels[c.ID] = &c
+	}
+	for i := range r.Groups {
+		g := r.Groups[i]
+		st.groups[g.ID] = &g
+	}
+	for i := range r.IMS {
+		im := r.IMS[i]
+		st.ims[im.ID] = &im
+	}
+	for i := range r.Users {
+		u := r.Users[i]
+		st.users[u.ID] = &u
+	}
+	for i := range r.Bots {
+		b := r.Bots[i]
+		st.bots[b.ID] = &b
+	}
+}
+
+// baseChannel returns the base channel of a channel, group or IM with the given ID
+func (st *State) baseChannel(id string) *BaseChannel {
+	if c, ok := st.channels[id]; ok {
+		return &c.BaseChannel
+	}
+	if g, ok := st.groups[id]; ok {
+		return &g.BaseChannel
+	}
+	if im, ok := st.ims[id]; ok {
+		return &im.BaseChannel
+	}
+	return nil
+}
+
+// Apply updates the state with the given event. Events that do not change the state are ignored.
+func (st *State) Apply(e Event) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	switch e := e.(type) {
+	case *ChannelCreatedEvent:
+		c := e.Channel
+		st.channels[c.ID] = &c
+	case *ChannelJoinedEvent:
+		c := e.Channel
+		c.IsMember = true
+		st.channels[c.ID] = &c
+	case *ChannelLeftEvent:
+		if c, ok := st.channels[e.Channel]; ok {
+			c.IsMember = false
+		}
+	case *ChannelRenameEvent:
+		if c, ok := st.channels[e.Channel.ID]; ok {
+			c.Name = e.Channel.Name
+		}
+	case *ChannelArchiveEvent:
+		if c, ok := st.channels[e.Channel]; ok {
+			c.IsArchived = true
+		}
+	case *ChannelUnarchiveEvent:
+		if c, ok := st.channels[e.Channel]; ok {
+			c.IsArchived = false
+		}
+	case *ChannelDeletedEvent:
+		delete(st.channels, e.Channel)
+	case *MemberJoinedChannelEvent:
+		if c := st.baseChannel(e.Channel); c != nil {
+			for _, m := range c.Members {
+				if m == e.User {
+					return
+				}
+			}
+			// Copy on append so snapshots sharing the array do not see the new member
+			c.Members = append(c.Members[:len(c.Members):len(c.Members)], e.User)
+		}
+	case *MemberLeftChannelEvent:
+		if c := st.baseChannel(e.Channel); c != nil {
+			for i, m := range c.Members {
+				if m == e.User {
+					c.Members = append(c.Members[:i:i], c.Members[i+1:]...)
+					break
+				}
+			}
+		}
+	case *GroupJoinedEvent:
+		g := e.Channel
+		st.groups[g.ID] = &g
+	case *GroupLeftEvent:
+		delete(st.groups, e.Channel)
+	case *GroupRenameEvent:
+		if g, ok := st.groups[e.Channel.ID]; ok {
+			g.Name = e.Channel.Name
+		}
+	case *GroupArchiveEvent:
+		if g, ok := st.groups[e.Channel]; ok {
+			g.IsArchived = true
+		}
+	case *GroupUnarchiveEvent:
+		if g, ok := st.groups[e.Channel]; ok {
+			g.IsArchived = false
+		}
+	case *GroupOpenEvent:
+		if g, ok := st.groups[e.Channel]; ok {
+			g.IsOpen = true
+		}
+	case *GroupCloseEvent:
+		if g, ok := st.groups[e.Channel]; ok {
+			g.IsOpen = false
+		}
+	case *IMCreatedEvent:
+		im := e.Channel
+		if im.User == "" {
+			im.User = e.User
+		}
+		st.ims[im.ID] = &im
+	case *IMOpenEvent:
+		if im, ok := st.ims[e.Channel]; ok {
+			im.IsOpen = true
+		}
+	case *IMCloseEvent:
+		if im, ok := st.ims[e.Channel]; ok {
+			im.IsOpen = false
+		}
+	case *UserChangeEvent:
+		u := e.User
+		st.users[u.ID] = &u
+	case *TeamJoinEvent:
+		u := e.User
+		st.users[u.ID] = &u
+	case *PresenceChangeEvent:
+		users := e.Users
+		if e.User != "" {
+			users = append(users[:len(users):len(users)], e.User)
+		}
+		for _, id := range users {
+			if u, ok := st.users[id]; ok {
+				u.Presence = e.Presence
+			}
+		}
+	case *BotAddedEvent:
+		b := e.Bot
+		st.bots[b.ID] = &b
+	case *BotChangedEvent:
+		b := e.Bot
+		st.bots[b.ID] = &b
+	case *TeamRenameEvent:
+		st.team.Name = e.Name
+	}
+}
+
+// Team returns the team information
+func (st *State) Team() Team {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	return st.team
+}
+
+// Channel returns the channel with the given ID
+func (st *State) Channel(id string) (Channel, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	if c, ok := st.channels[id]; ok {
+		return *c, true
+	}
+	return Channel{}, false
+}
+
+// ChannelByName returns the channel with the given name
+func (st *State) ChannelByName(name string) (Channel, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	for _, c := range st.channels {
+		if c.Name == name {
+			return *c, true
+		}
+	}
+	return Channel{}, false
+}
+
+// Channels returns all the channels
+func (st *State) Channels() []Channel {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	channels := make([]Channel, 0, len(st.channels))
+	for _, c := range st.channels {
+		channels = append(channels, *c)
+	}
+	return channels
+}
+
+// Group returns the group with the given ID
+func (st *State) Group(id string) (Group, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	if g, ok := st.groups[id]; ok {
+		return *g, true
+	}
+	return Group{}, false
+}
+
+// GroupByName returns the group with the given name
+func (st *State) GroupByName(name string) (Group, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	for _, g := range st.groups {
+		if g.Name == name {
+			return *g, true
+		}
+	}
+	return Group{}, false
+}
+
+// Groups returns all the groups
+func (st *State) Groups() []Group {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	groups := make([]Group, 0, len(st.groups))
+	for _, g := range st.groups {
+		groups = append(groups, *g)
+	}
+	return groups
+}
+
+// IM returns the IM with the given ID
+func (st *State) IM(id string) (IM, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	if im, ok := st.ims[id]; ok {
+		return *im, true
+	}
+	return IM{}, false
+}
+
+// IMByUser returns the IM with the given user
+func (st *State) IMByUser(user string) (IM, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	for _, im := range st.ims {
+		if im.User == user {
+			return *im, true
+		}
+	}
+	return IM{}, false
+}
+
+// IMs returns all the IMs
+func (st *State) IMs() []IM {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	ims := make([]IM, 0, len(st.ims))
+	for _, im := range st.ims {
+		ims = append(ims, *im)
+	}
+	return ims
+}
+
+// User returns the user with the given ID
+func (st *State) User(id string) (User, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	if u, ok := st.users[id]; ok {
+		return *u, true
+	}
+	return User{}, false
+}
+
+// UserByName returns the user with the given name
+func (st *State) UserByName(name string) (User, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	for _, u := range st.users {
+		if u.Name == name {
+			return *u, true
+		}
+	}
+	return User{}, false
+}
+
+// Users returns all the users
+func (st *State) Users() []User {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	users := make([]User, 0, len(st.users))
+	for _, u := range st.users {
+		users = append(users, *u)
+	}
+	return users
+}
+
+// Bot returns the bot with the given ID
+func (st *State) Bot(id string) (Bot, bool) {
+	st.mutex.RLock()
+	defer st.mutex.RUnlock()
+	if b, ok := st.bots[id]; ok {
+		return *b, true
+	}
+	return Bot{}, false
+}
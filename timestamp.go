@@ -0,0 +1,132 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timestamp is a Slack message timestamp like "1234567890.123456". The microseconds are part of the
+// message identity so the timestamp is kept as the original string and never rounded.
+type Timestamp string
+
+// ParseTimestamp validates the Slack timestamp
+func ParseTimestamp(ts string) (Timestamp, error) {
+	if _, _, err := Timestamp(ts).parts(); err != nil {
+		return "", err
+	}
+	return Timestamp(ts), nil
+}
+
+// NewTimestamp returns the timestamp of the time with microsecond precision
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp(fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/1000))
+}
+
+// parts returns the seconds and microseconds of the timestamp
+func (ts Timestamp) parts() (sec, usec int64, err error) {
+	s, frac := string(ts), ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s, frac = s[:i], s[i+1:]
+	}
+	// Both parts must be plain digits so signs and exponents are rejected
+	valid := s != "" && len(frac) <= 6 && strings.Trim(s, "0123456789") == "" && strings.Trim(frac, "0123456789") == ""
+	if valid {
+		sec, err = strconv.ParseInt(s, 10, 64)
+		if err == nil && frac != "" {
+			usec, err = strconv.ParseInt(frac+strings.Repeat("0", 6-len(frac)), 10, 64)
+		}
+	}
+	if !valid || err != nil {
+		return 0, 0, fmt.Errorf("Invalid timestamp [%s]", string(ts))
+	}
+	return sec, usec, nil
+}
+
+// IsZero returns true for an empty timestamp
+func (ts Timestamp) IsZero() bool {
+	return ts == ""
+}
+
+// Valid returns true if the timestamp can be parsed
+func (ts Timestamp) Valid() bool {
+	_, _, err := ts.parts()
+	return err == nil
+}
+
+// Time returns the time of the timestamp with microsecond precision or the zero time if invalid
+func (ts Timestamp) Time() time.Time {
+	sec, usec, err := ts.parts()
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(sec, usec*1000)
+}
+
+// Compare returns -1, 0 or 1 if the timestamp is before, equal or after the other. Invalid and
+// empty timestamps are before all valid ones.
+func (ts Timestamp) Compare(other Timestamp) int {
+	sec1, usec1, err1 := ts.parts()
+	sec2, usec2, err2 := other.parts()
+	switch {
+	case err1 != nil && err2 != nil:
+		return strings.Compare(string(ts), string(other))
+	case err1 != nil:
+		return -1
+	case err2 != nil:
+		return 1
+	case sec1 < sec2 || (sec1 == sec2 && usec1 < usec2):
+		return -1
+	case sec1 > sec2 || usec1 > usec2:
+		return 1
+	}
+	return 0
+}
+
+// Before returns true if the timestamp is before the other
+func (ts Timestamp) Before(other Timestamp) bool {
+	return ts.Compare(other) < 0
+}
+
+// After returns true if the timestamp is after the other
+func (ts Timestamp) After(other Timestamp) bool {
+	return ts.Compare(other) > 0
+}
+
+// String returns the timestamp as sent by Slack
+func (ts Timestamp) String() string {
+	return string(ts)
+}
+
+// UnmarshalJSON accepts the timestamp as a string or as a number which some events use
+func (ts *Timestamp) UnmarshalJSON(p []byte) error {
+	p = bytes.TrimSpace(p)
+	if bytes.Equal(p, []byte("null")) {
+		return nil
+	}
+	if len(p) > 0 && p[0] == '"' {
+		var s string
+		if err := json.Unmarshal(p, &s); err != nil {
+			return err
+		}
+		*ts = Timestamp(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(p, &n); err != nil {
+		return errors.New("Invalid timestamp " + string(p))
+	}
+	*ts = Timestamp(n.String())
+	return nil
+}
+
+// Timestamps sorts timestamps from the oldest to the newest
+type Timestamps []Timestamp
+
+func (t Timestamps) Len() int           { return len(t) }
+func (t Timestamps) Less(i, j int) bool { return t[i].Before(t[j]) }
+func (t Timestamps) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
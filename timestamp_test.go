@@ -0,0 +1,130 @@
+package slack
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestTimestampParts(t *testing.T) {
+	tests := []struct {
+		ts        Timestamp
+		sec, usec int64
+		ok        bool
+	}{
+		{"1234567890.123456", 1234567890, 123456, true},
+		{"1234567890.1", 1234567890, 100000, true},
+		{"1234567890.000001", 1234567890, 1, true},
+		{"1234567890", 1234567890, 0, true},
+		{"", 0, 0, false},
+		{".123456", 0, 0, false},
+		{"1234567890.1234567", 0, 0, false},
+		{"-1234567890.123456", 0, 0, false},
+		{"+1234567890.123456", 0, 0, false},
+		{"1234567890.-12345", 0, 0, false},
+		{"1.234567890e9", 0, 0, false},
+		{"1e9", 0, 0, false},
+		{"1234567890.12a456", 0, 0, false},
+		{"99999999999999999999", 0, 0, false},
+	}
+	for _, tt := range tests {
+		sec, usec, err := tt.ts.parts()
+		if (err == nil) != tt.ok {
+			t.Errorf("parts(%q) err = %v, want ok %v", tt.ts, err, tt.ok)
+			continue
+		}
+		if sec != tt.sec || usec != tt.usec {
+			t.Errorf("parts(%q) = %d, %d, want %d, %d", tt.ts, sec, usec, tt.sec, tt.usec)
+		}
+		if tt.ts.Valid() != tt.ok {
+			t.Errorf("%q Valid = %v, want %v", tt.ts, !tt.ok, tt.ok)
+		}
+	}
+}
+
+func TestTimestampTime(t *testing.T) {
+	at := time.Unix(1234567890, 123456789)
+	ts := NewTimestamp(at)
+	if ts != "1234567890.123456" {
+		t.Errorf("NewTimestamp = %q, want 1234567890.123456", ts)
+	}
+	if got := ts.Time(); !got.Equal(time.Unix(1234567890, 123456000)) {
+		t.Errorf("Time = %v, want microsecond precision", got)
+	}
+	if !Timestamp("bad").Time().IsZero() {
+		t.Error("invalid timestamp has a non zero time")
+	}
+}
+
+func TestTimestampCompare(t *testing.T) {
+	tests := []struct {
+		a, b Timestamp
+		want int
+	}{
+		{"1234567890.123456", "1234567890.123456", 0},
+		{"1234567890.1", "1234567890.100000", 0},
+		{"1234567890.123456", "1234567890.123457", -1},
+		{"1234567890.9", "1234567891.0", -1},
+		{"1234567891", "1234567890.999999", 1},
+		// Not a string comparison - 9 seconds is before 10 seconds
+		{"9.000000", "10.000000", -1},
+		{"", "1234567890.123456", -1},
+		{"1234567890.123456", "bad", 1},
+		{"", "", 0},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := tt.b.Compare(tt.a); got != -tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+	ts := Timestamps{"1234567890.2", "", "10.5", "1234567890.000010"}
+	sort.Sort(ts)
+	want := Timestamps{"", "10.5", "1234567890.000010", "1234567890.2"}
+	for i := range want {
+		if ts[i] != want[i] {
+			t.Errorf("sorted = %q, want %q", ts, want)
+			break
+		}
+	}
+}
+
+func TestTimestampJSON(t *testing.T) {
+	var m struct {
+		TS Timestamp `json:"ts"`
+	}
+	for in, want := range map[string]Timestamp{
+		`{"ts":"1234567890.123456"}`: "1234567890.123456",
+		`{"ts":1234567890.123456}`:   "1234567890.123456",
+		`{"ts":1234567890}`:          "1234567890",
+		`{"ts":null}`:                "",
+	} {
+		m.TS = ""
+		if err := json.Unmarshal([]byte(in), &m); err != nil {
+			t.Errorf("unmarshal %s - %v", in, err)
+			continue
+		}
+		if m.TS != want {
+			t.Errorf("unmarshal %s = %q, want %q", in, m.TS, want)
+		}
+	}
+	// Trailing zeros are part of the message identity so they survive a round trip
+	m.TS = "1234567890.123400"
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"ts":"1234567890.123400"}` {
+		t.Errorf("marshal = %s", b)
+	}
+	m.TS = ""
+	if err = json.Unmarshal(b, &m); err != nil || m.TS != "1234567890.123400" {
+		t.Errorf("round trip = %q, %v", m.TS, err)
+	}
+	if err = json.Unmarshal([]byte(`{"ts":true}`), &m); err == nil {
+		t.Error("boolean timestamp accepted")
+	}
+}
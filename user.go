@@ -1,6 +1,7 @@
 package slack
 
 import (
+	"context"
 	"errors"
 	"net/url"
 	"strings"
@@ -63,7 +64,8 @@ type UserInfoResponse struct {
 // UserListResponse holds the response for the user list request
 type UserListResponse struct {
 	slackResponse
-	Members []User `json:"members"`
+	Members          []User           `json:"members"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
 }
 
 // UserPresence contains details about a user online status
@@ -104,9 +106,14 @@ type TeamInfoResponse struct {
 
 // TeamInfo returns info about the team
 func (s *Slack) TeamInfo() (*TeamInfoResponse, error) {
+	return s.TeamInfoContext(context.Background())
+}
+
+// TeamInfoContext is TeamInfo with a custom context
+func (s *Slack) TeamInfoContext(ctx context.Context) (*TeamInfoResponse, error) {
 	params := url.Values{}
 	r := &TeamInfoResponse{}
-	err := s.do("team.info", params, r)
+	err := s.do(ctx, "team.info", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -115,9 +122,14 @@ func (s *Slack) TeamInfo() (*TeamInfoResponse, error) {
 
 // UserInfo returns info about the requested user
 func (s *Slack) UserInfo(user string) (*UserInfoResponse, error) {
+	return s.UserInfoContext(context.Background(), user)
+}
+
+// UserInfoContext is UserInfo with a custom context
+func (s *Slack) UserInfoContext(ctx context.Context, user string) (*UserInfoResponse, error) {
 	params := url.Values{"user": {user}}
 	r := &UserInfoResponse{}
-	err := s.do("users.info", params, r)
+	err := s.do(ctx, "users.info", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -126,9 +138,14 @@ func (s *Slack) UserInfo(user string) (*UserInfoResponse, error) {
 
 // UserList returns the list of users
 func (s *Slack) UserList() (*UserListResponse, error) {
+	return s.UserListContext(context.Background())
+}
+
+// UserListContext is UserList with a custom context
+func (s *Slack) UserListContext(ctx context.Context) (*UserListResponse, error) {
 	params := url.Values{}
 	r := &UserListResponse{}
-	err := s.do("users.list", params, r)
+	err := s.do(ctx, "users.list", params, r)
 	if err != nil {
 		return nil, err
 	}
@@ -156,6 +173,11 @@ const (
 
 // InviteToSlack invites the given user to your team. You can use inviteType (0 - regular, 1 - restricted with a list of channels, 2 - single channel user)
 func (s *Slack) InviteToSlack(invitee UserInviteDetails, channels []string, inviteType InviteeType) error {
+	return s.InviteToSlackContext(context.Background(), invitee, channels, inviteType)
+}
+
+// InviteToSlackContext is InviteToSlack with a custom context
+func (s *Slack) InviteToSlackContext(ctx context.Context, invitee UserInviteDetails, channels []string, inviteType InviteeType) error {
 	if invitee.Email == "" {
 		return errors.New("Missing email in the invitee")
 	}
@@ -183,5 +205,5 @@ func (s *Slack) InviteToSlack(invitee UserInviteDetails, channels []string, invi
 		params.Set("ultra_restricted", "1")
 	}
 	r := &slackResponse{}
-	return s.do("users.admin.invite", params, r)
+	return s.do(ctx, "users.admin.invite", params, r)
 }
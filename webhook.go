@@ -0,0 +1,136 @@
+package slack
+
+import (
+	"context"
+	"net/url"
+)
+
+// Errors returned by incoming webhooks as the Error ID - see https://api.slack.com/messaging/webhooks#handling_errors
+const (
+	WebhookInvalidPayload         = "invalid_payload"
+	WebhookUserNotFound           = "user_not_found"
+	WebhookChannelNotFound        = "channel_not_found"
+	WebhookChannelIsArchived      = "channel_is_archived"
+	WebhookActionProhibited       = "action_prohibited"
+	WebhookPostingToGeneralDenied = "posting_to_general_channel_denied"
+	WebhookTooManyAttachments     = "too_many_attachments"
+	WebhookNoService              = "no_service"
+	WebhookNoServiceID            = "no_service_id"
+	WebhookNoTeam                 = "no_team"
+	WebhookTeamDisabled           = "team_disabled"
+	WebhookInvalidToken           = "invalid_token"
+)
+
+// webhookErrors describes the webhook errors
+var webhookErrors = map[string]string{
+	WebhookInvalidPayload:         "The payload is malformed or missing the text",
+	WebhookUserNotFound:           "The user does not exist",
+	WebhookChannelNotFound:        "The channel does not exist or the webhook cannot post to it",
+	WebhookChannelIsArchived:      "The channel is archived",
+	WebhookActionProhibited:       "An admin restricted posting to the channel",
+	WebhookPostingToGeneralDenied: "Only admins can post to the general channel",
+	WebhookTooManyAttachments:     "The message has more than 100 attachments",
+	WebhookNoService:              "The webhook is disabled, removed or invalid",
+	WebhookNoServiceID:            "The webhook URL is missing the service ID",
+	WebhookNoTeam:                 "The webhook team is missing or invalid",
+	WebhookTeamDisabled:           "The team of the webhook is disabled",
+	WebhookInvalidToken:           "The webhook token is invalid",
+}
+
+// webhookPayload is the JSON posted to the webhook
+type webhookPayload struct {
+	Text        string       `json:"text,omitempty"`
+	Channel     string       `json:"channel,omitempty"`
+	Username    string       `json:"username,omitempty"`
+	IconURL     string       `json:"icon_url,omitempty"`
+	IconEmoji   string       `json:"icon_emoji,omitempty"`
+	Parse       string       `json:"parse,omitempty"`
+	LinkNames   int          `json:"link_names,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	Blocks      Blocks       `json:"blocks,omitempty"`
+	UnfurlLinks bool         `json:"unfurl_links,omitempty"`
+	UnfurlMedia bool         `json:"unfurl_media,omitempty"`
+	ThreadID    Timestamp    `json:"thread_ts,omitempty"`
+}
+
+// Webhook posts messages to an incoming webhook URL - see https://api.slack.com/messaging/webhooks
+type Webhook struct {
+	s   *Slack
+	url string
+}
+
+// NewWebhook creates a webhook client for the given URL. It does not need a token and
+// accepts the same transport options as New, for example SetHTTPClient, SetErrorLog,
+// SetTraceLog, SetRetryPolicy and SetRateLimiter. The rate limiter limits the webhook
+// like chat.postMessage to a channel.
+func NewWebhook(webhookURL string, options ...OptionFunc) (*Webhook, error) {
+	s, err := newClient(options)
+	if err != nil {
+		return nil, err
+	}
+	// The URL holds the webhook secret so it is never logged or returned in errors
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		e := newError("bad_url", "Invalid webhook URL")
+		s.errorf("%s\n", e.Error())
+		return nil, e
+	}
+	return &Webhook{s: s, url: webhookURL}, nil
+}
+
+// Post posts the message to the webhook. The channel, username and icon are only honored
+// by legacy webhooks.
+func (w *Webhook) Post(m *PostMessageRequest, escape bool) error {
+	return w.PostContext(context.Background(), m, escape)
+}
+
+// PostContext is Post with a custom context
+func (w *Webhook) PostContext(ctx context.Context, m *PostMessageRequest, escape bool) error {
+	p := &webhookPayload{
+		Text:        m.Text,
+		Channel:     m.Channel,
+		Username:    m.Username,
+		IconURL:     m.IconURL,
+		IconEmoji:   m.IconEmoji,
+		Parse:       m.Parse,
+		LinkNames:   m.LinkNames,
+		Attachments: m.Attachments,
+		Blocks:      m.Blocks,
+		UnfurlLinks: m.UnfurlLinks,
+		UnfurlMedia: m.UnfurlMedia,
+		ThreadID:    m.ThreadID,
+	}
+	if escape {
+		p.Text = escapeText(p.Text)
+	}
+	if len(p.Blocks) > 0 {
+		if err := p.Blocks.Validate(); err != nil {
+			return err
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		if err := w.s.wait(ctx, "chat.postMessage", w.url); err != nil {
+			return err
+		}
+		err := w.s.postJSON(ctx, w.url, p)
+		if ue, ok := err.(*url.Error); ok {
+			// Transport errors include the URL so keep only the scheme and host
+			if u, perr := url.Parse(ue.URL); perr == nil {
+				ue.URL = u.Scheme + "://" + u.Host
+			}
+		}
+		if e, ok := err.(*Error); ok {
+			if detail, ok := webhookErrors[e.ID]; ok {
+				e.Detail = detail
+			}
+		}
+		rl, ok := err.(*RateLimitedError)
+		if !ok || !w.s.retry.shouldRetry(attempt, rl.RetryAfter) {
+			return err
+		}
+		w.s.errorf("Webhook request was rate limited, retrying in %v\n", rl.RetryAfter)
+		if err = sleepContext(ctx, rl.RetryAfter); err != nil {
+			return err
+		}
+	}
+}